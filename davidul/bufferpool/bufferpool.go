@@ -0,0 +1,141 @@
+package bufferpool
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/davidul/buffers/davidul/seekbuffer"
+)
+
+// capacities of the buffers handed out by the default pool
+var DefaultSizeClasses = []int{1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20}
+
+// pool of SeekBuffers grouped by capacity
+type Pool struct {
+	classes []int
+	pools   []sync.Pool
+
+	gets     atomic.Uint64
+	hits     atomic.Uint64
+	puts     atomic.Uint64
+	discards atomic.Uint64
+}
+
+// counters of a pool
+type Stats struct {
+	// buffers requested
+	Gets uint64
+	// requests served from the pool
+	Hits uint64
+	// buffers returned to the pool
+	Puts uint64
+	// buffers dropped because they fit no size class
+	Discards uint64
+}
+
+// ratio of requests served from the pool, 0 if nothing was requested
+func (s Stats) HitRate() float64 {
+	if s.Gets == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Gets)
+}
+
+// pool with the given capacities in ascending order, DefaultSizeClasses
+// are used when none are given
+func NewPool(classes ...int) *Pool {
+	if len(classes) == 0 {
+		classes = DefaultSizeClasses
+	}
+	c := make([]int, len(classes))
+	copy(c, classes)
+	return &Pool{
+		classes: c,
+		pools:   make([]sync.Pool, len(c)),
+	}
+}
+
+// returns an empty buffer with room for at least size bytes
+func (p *Pool) Get(size int) *seekbuffer.SeekBuffer {
+	p.gets.Add(1)
+	i := p.classFor(size)
+	if i == -1 {
+		return seekbuffer.NewSeekBufferWithCapacity(size)
+	}
+	if b, ok := p.pools[i].Get().(*seekbuffer.SeekBuffer); ok {
+		p.hits.Add(1)
+		return b
+	}
+	return seekbuffer.NewSeekBufferWithCapacity(p.classes[i])
+}
+
+// resets b and returns it to the pool, b must not be used afterwards
+func (p *Pool) Put(b *seekbuffer.SeekBuffer) {
+	if b == nil {
+		return
+	}
+	// views share storage with their parent
+	if b.ReadOnly() {
+		p.discards.Add(1)
+		return
+	}
+	i := p.classOf(b.Cap())
+	if i == -1 {
+		p.discards.Add(1)
+		return
+	}
+	b.Reset()
+	p.puts.Add(1)
+	p.pools[i].Put(b)
+}
+
+// returns the counters of the pool
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Gets:     p.gets.Load(),
+		Hits:     p.hits.Load(),
+		Puts:     p.puts.Load(),
+		Discards: p.discards.Load(),
+	}
+}
+
+// index of the smallest class holding size bytes, -1 if too large
+func (p *Pool) classFor(size int) int {
+	for i, c := range p.classes {
+		if size <= c {
+			return i
+		}
+	}
+	return -1
+}
+
+// index of the largest class a buffer with capacity c can serve,
+// -1 if it is smaller than every class or larger than the biggest
+func (p *Pool) classOf(c int) int {
+	if len(p.classes) == 0 || c > 2*p.classes[len(p.classes)-1] {
+		return -1
+	}
+	for i := len(p.classes) - 1; i >= 0; i-- {
+		if c >= p.classes[i] {
+			return i
+		}
+	}
+	return -1
+}
+
+var defaultPool = NewPool()
+
+// returns an empty buffer with room for at least size bytes from the default pool
+func Get(size int) *seekbuffer.SeekBuffer {
+	return defaultPool.Get(size)
+}
+
+// resets b and returns it to the default pool
+func Put(b *seekbuffer.SeekBuffer) {
+	defaultPool.Put(b)
+}
+
+// returns the counters of the default pool
+func DefaultStats() Stats {
+	return defaultPool.Stats()
+}
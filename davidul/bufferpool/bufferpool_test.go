@@ -0,0 +1,87 @@
+package bufferpool
+
+import (
+	"testing"
+
+	"github.com/davidul/buffers/davidul/seekbuffer"
+)
+
+func TestGet(t *testing.T) {
+	p := NewPool(16, 64)
+	b := p.Get(10)
+	if cap(b.Bytes()) != 16 {
+		t.Errorf("cap should be 16, but got %d", cap(b.Bytes()))
+	}
+	b = p.Get(100)
+	if cap(b.Bytes()) != 100 {
+		t.Errorf("cap should be 100, but got %d", cap(b.Bytes()))
+	}
+	if p.Stats().Gets != 2 {
+		t.Errorf("gets should be 2, but got %d", p.Stats().Gets)
+	}
+}
+
+func TestPut(t *testing.T) {
+	p := NewPool(16, 64)
+	b := p.Get(10)
+	b.Append([]byte{1, 2, 3})
+	b.Seek(2)
+	b.SetMaxSize(3)
+	p.Put(b)
+	if p.Stats().Puts != 1 {
+		t.Errorf("puts should be 1, but got %d", p.Stats().Puts)
+	}
+	if b.Len() != 0 {
+		t.Errorf("len should be 0, but got %d", b.Len())
+	}
+	if b.MaxSize() != 0 {
+		t.Errorf("maxSize should be 0, but got %d", b.MaxSize())
+	}
+}
+
+func TestPut_Safe(t *testing.T) {
+	p := NewPool(16, 64)
+	p.Put(seekbuffer.NewSafeSeekBuffer(make([]byte, 20)))
+	b := p.Get(16)
+	if p.Stats().Hits == 0 {
+		t.Skip("pooled buffer was dropped")
+	}
+	b.Append([]byte{1})
+	b.Bytes()[0] = 9
+	if b.Bytes()[0] != 9 {
+		t.Errorf("pooled buffer should leave safe mode")
+	}
+}
+
+func TestPut_Discard(t *testing.T) {
+	p := NewPool(16, 64)
+	p.Put(seekbuffer.NewSeekBufferWithCapacity(8))
+	p.Put(seekbuffer.NewSeekBufferWithCapacity(1000))
+	view, _ := seekbuffer.NewSeekBuffer(make([]byte, 32)).View(0, 32)
+	p.Put(view)
+	if p.Stats().Discards != 3 {
+		t.Errorf("discards should be 3, but got %d", p.Stats().Discards)
+	}
+}
+
+func TestHitRate(t *testing.T) {
+	s := Stats{Gets: 4, Hits: 1}
+	if s.HitRate() != 0.25 {
+		t.Errorf("hit rate should be 0.25, but got %f", s.HitRate())
+	}
+	if (Stats{}).HitRate() != 0 {
+		t.Errorf("hit rate should be 0")
+	}
+}
+
+func TestDefaultPool(t *testing.T) {
+	puts := DefaultStats().Puts
+	b := Get(100)
+	if cap(b.Bytes()) != 1<<10 {
+		t.Errorf("cap should be 1024, but got %d", cap(b.Bytes()))
+	}
+	Put(b)
+	if DefaultStats().Puts != puts+1 {
+		t.Errorf("puts should be %d, but got %d", puts+1, DefaultStats().Puts)
+	}
+}
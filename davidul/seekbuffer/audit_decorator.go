@@ -0,0 +1,145 @@
+package seekbuffer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+type principalKey struct{}
+
+// returns a copy of ctx carrying principal, AuditDecorator records it
+// for writes made with the context
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// returns the principal carried by ctx
+func PrincipalFromContext(ctx context.Context) (string, bool) {
+	principal, ok := ctx.Value(principalKey{}).(string)
+	return principal, ok
+}
+
+// one line of the audit trail
+type AuditRecord struct {
+	Time time.Time `json:"time"`
+	Op   string    `json:"op"`
+	// bytes written
+	Bytes int `json:"bytes"`
+	// size of the content before the operation
+	SizeBefore int    `json:"size_before"`
+	Principal  string `json:"principal,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// decorator writing one JSON line to a sink for every write, append and
+// close of the wrapped buffer
+type AuditDecorator struct {
+	buffer SeekableBuffer
+	// serializes lines written to the sink
+	mu   sync.Mutex
+	sink io.Writer
+}
+
+var _ ContextBuffer = (*AuditDecorator)(nil)
+
+// wraps buffer, the audit trail is written to sink
+func NewAuditDecorator(buffer SeekableBuffer, sink io.Writer) *AuditDecorator {
+	return &AuditDecorator{
+		buffer: buffer,
+		sink:   sink,
+	}
+}
+
+// writes a record of op to the sink, returns err joined with a failure
+// to write the record
+func (d *AuditDecorator) audit(ctx context.Context, op Operation, n int, sizeBefore int, err error) error {
+	record := AuditRecord{
+		Time:       time.Now().UTC(),
+		Op:         op.String(),
+		Bytes:      n,
+		SizeBefore: sizeBefore,
+	}
+	record.Principal, _ = PrincipalFromContext(ctx)
+	if err != nil {
+		record.Error = err.Error()
+	}
+	line, aerr := json.Marshal(record)
+	if aerr == nil {
+		d.mu.Lock()
+		_, aerr = d.sink.Write(append(line, '\n'))
+		d.mu.Unlock()
+	}
+	if aerr != nil {
+		return errors.Join(err, aerr)
+	}
+	return err
+}
+
+func (d *AuditDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+// appends content and records it
+func (d *AuditDecorator) Append(src []byte) error {
+	sizeBefore := len(d.buffer.Bytes())
+	err := d.buffer.Append(src)
+	n := len(src)
+	if err != nil {
+		n = 0
+	}
+	return d.audit(context.Background(), OpAppend, n, sizeBefore, err)
+}
+
+// writes content and records it
+func (d *AuditDecorator) Write(src []byte) (int, error) {
+	return d.WriteContext(context.Background(), src)
+}
+
+// writes content and records it with the principal carried by ctx
+func (d *AuditDecorator) WriteContext(ctx context.Context, src []byte) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	sizeBefore := len(d.buffer.Bytes())
+	n, err := d.buffer.Write(src)
+	return n, d.audit(ctx, OpWrite, n, sizeBefore, err)
+}
+
+func (d *AuditDecorator) Read(dst []byte) (int, error) {
+	return d.buffer.Read(dst)
+}
+
+// reads like Read unless ctx is already done
+func (d *AuditDecorator) ReadContext(ctx context.Context, dst []byte) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return d.buffer.Read(dst)
+}
+
+func (d *AuditDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *AuditDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+// closes the buffer and records it
+func (d *AuditDecorator) Close() error {
+	sizeBefore := len(d.buffer.Bytes())
+	err := d.buffer.Close()
+	return d.audit(context.Background(), OpClose, 0, sizeBefore, err)
+}
+
+func (d *AuditDecorator) ReadBytes(c byte) ([]byte, error) {
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *AuditDecorator) Len() int {
+	return d.buffer.Len()
+}
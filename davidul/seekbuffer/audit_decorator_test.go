@@ -0,0 +1,60 @@
+package seekbuffer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestAuditDecorator_Records(t *testing.T) {
+	var sink bytes.Buffer
+	decorator := NewAuditDecorator(NewEmptySeekBuffer(), &sink)
+	decorator.Append([]byte("abc"))
+	decorator.WriteContext(WithPrincipal(context.Background(), "alice"), []byte("de"))
+	decorator.Read(make([]byte, 2))
+	decorator.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(&sink)
+	for scanner.Scan() {
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 3 {
+		t.Fatalf("should record 3 mutating operations, but got %d", len(records))
+	}
+	if records[0].Op != "append" || records[0].Bytes != 3 || records[0].Principal != "" {
+		t.Errorf("first record should be an anonymous append, but got %+v", records[0])
+	}
+	if records[1].Op != "write" || records[1].SizeBefore != 3 || records[1].Principal != "alice" {
+		t.Errorf("second record should be a write by alice, but got %+v", records[1])
+	}
+	if records[2].Op != "close" || records[2].Time.IsZero() {
+		t.Errorf("third record should be a timestamped close, but got %+v", records[2])
+	}
+}
+
+func TestAuditDecorator_FailedWrite(t *testing.T) {
+	var sink bytes.Buffer
+	decorator := NewAuditDecorator(NewSeekBufferWithLimit(1), &sink)
+	if _, err := decorator.Write([]byte("ab")); err != ErrBufferFull {
+		t.Errorf("write should fail with ErrBufferFull, but got %v", err)
+	}
+	var record AuditRecord
+	json.Unmarshal(sink.Bytes(), &record)
+	if record.Error != ErrBufferFull.Error() {
+		t.Errorf("record should carry the error, but got %+v", record)
+	}
+}
+
+func TestAuditDecorator_SinkError(t *testing.T) {
+	decorator := NewAuditDecorator(NewEmptySeekBuffer(), &failingWriter{fail: true})
+	if err := decorator.Append([]byte("a")); err == nil {
+		t.Errorf("sink failure should be reported")
+	}
+}
@@ -0,0 +1,31 @@
+package seekbuffer
+
+import (
+	"crypto/sha256"
+	"hash"
+	"hash/crc32"
+)
+
+// IEEE CRC-32 checksum of the whole buffer
+func (s *SeekBuffer) CRC32() uint32 {
+	return crc32.ChecksumIEEE(s.buffer)
+}
+
+// SHA-256 digest of the whole buffer
+func (s *SeekBuffer) SHA256() [sha256.Size]byte {
+	return sha256.Sum256(s.buffer)
+}
+
+// feeds the whole buffer to h and returns the resulting sum, h is reset first
+func (s *SeekBuffer) Sum(h hash.Hash) []byte {
+	h.Reset()
+	h.Write(s.buffer)
+	return h.Sum(nil)
+}
+
+// feeds the unread content to h and returns the resulting sum, h is reset first
+func (s *SeekBuffer) SumUnread(h hash.Hash) []byte {
+	h.Reset()
+	h.Write(s.unread())
+	return h.Sum(nil)
+}
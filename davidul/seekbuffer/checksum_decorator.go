@@ -0,0 +1,217 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+)
+
+// checksum stored with every frame of ChecksumDecorator
+type ChecksumAlgorithm int
+
+const (
+	// 4 byte CRC-32 with the Castagnoli polynomial
+	ChecksumCRC32C ChecksumAlgorithm = iota
+	// 32 byte SHA-256 digest
+	ChecksumSHA256
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// returned when stored content does not match its checksum
+var ErrChecksumMismatch = errors.New("seekbuffer: checksum mismatch")
+
+func (a ChecksumAlgorithm) size() int {
+	if a == ChecksumSHA256 {
+		return sha256.Size
+	}
+	return crc32.Size
+}
+
+func (a ChecksumAlgorithm) sum(data []byte) []byte {
+	if a == ChecksumSHA256 {
+		s := sha256.Sum256(data)
+		return s[:]
+	}
+	return binary.BigEndian.AppendUint32(nil, crc32.Checksum(data, castagnoli))
+}
+
+// payload stored in the wrapped buffer
+type checksumFrame struct {
+	// offset of the first payload byte in the content
+	start int
+	size  int
+	// position of the payload in the wrapped buffer
+	pos      int
+	verified bool
+}
+
+// decorator storing every write as a frame of uvarint length, payload and
+// checksum in the wrapped buffer, frames are verified when first read
+type ChecksumDecorator struct {
+	buffer    SeekableBuffer
+	algorithm ChecksumAlgorithm
+	frames    []checksumFrame
+	size      int
+	offset    int
+}
+
+var _ SeekableBuffer = (*ChecksumDecorator)(nil)
+
+// wraps buffer, frames already stored in it are indexed but only verified
+// when read or by VerifyAll
+func NewChecksumDecorator(buffer SeekableBuffer, algorithm ChecksumAlgorithm) (*ChecksumDecorator, error) {
+	d := &ChecksumDecorator{
+		buffer:    buffer,
+		algorithm: algorithm,
+	}
+	content := buffer.Bytes()
+	for pos := 0; pos < len(content); {
+		n, k := binary.Uvarint(content[pos:])
+		// room left for the payload, compared without adding to n so a
+		// damaged length cannot overflow
+		rest := len(content) - pos - k - algorithm.size()
+		if k <= 0 || rest < 0 || n > uint64(rest) {
+			return nil, ErrInvalidEncoding
+		}
+		d.frames = append(d.frames, checksumFrame{start: d.size, size: int(n), pos: pos + k})
+		d.size += int(n)
+		pos += k + int(n) + algorithm.size()
+	}
+	return d, nil
+}
+
+// verifies frame i and returns its payload
+func (d *ChecksumDecorator) frame(i int) ([]byte, error) {
+	f := &d.frames[i]
+	content := d.buffer.Bytes()
+	payload := content[f.pos : f.pos+f.size]
+	if !f.verified {
+		stored := content[f.pos+f.size : f.pos+f.size+d.algorithm.size()]
+		if !bytes.Equal(stored, d.algorithm.sum(payload)) {
+			return nil, fmt.Errorf("%w: frame %d at offset %d", ErrChecksumMismatch, i, f.start)
+		}
+		f.verified = true
+	}
+	return payload, nil
+}
+
+// verifies every frame, including ones already verified
+func (d *ChecksumDecorator) VerifyAll() error {
+	for i := range d.frames {
+		d.frames[i].verified = false
+		if _, err := d.frame(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// index of the frame holding offset
+func (d *ChecksumDecorator) find(offset int) int {
+	return sort.Search(len(d.frames), func(i int) bool {
+		return d.frames[i].start+d.frames[i].size > offset
+	})
+}
+
+// returns the content without checksums, it is not verified
+func (d *ChecksumDecorator) Bytes() []byte {
+	b := make([]byte, 0, d.size)
+	content := d.buffer.Bytes()
+	for _, f := range d.frames {
+		b = append(b, content[f.pos:f.pos+f.size]...)
+	}
+	return b
+}
+
+// appends src with its checksum as a new frame
+func (d *ChecksumDecorator) Append(src []byte) error {
+	if len(src) == 0 {
+		return nil
+	}
+	frame := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(src)+d.algorithm.size()), uint64(len(src)))
+	k := len(frame)
+	frame = append(frame, src...)
+	frame = append(frame, d.algorithm.sum(src)...)
+	pos := len(d.buffer.Bytes())
+	if err := d.buffer.Append(frame); err != nil {
+		return err
+	}
+	d.frames = append(d.frames, checksumFrame{start: d.size, size: len(src), pos: pos + k, verified: true})
+	d.size += len(src)
+	return nil
+}
+
+// writes src with its checksum, alias for Append
+func (d *ChecksumDecorator) Write(src []byte) (int, error) {
+	if err := d.Append(src); err != nil {
+		return 0, err
+	}
+	return len(src), nil
+}
+
+// reads verified content into dst
+func (d *ChecksumDecorator) Read(dst []byte) (int, error) {
+	if d.offset >= d.size {
+		return 0, io.EOF
+	}
+	n := 0
+	for n < len(dst) && d.offset < d.size {
+		i := d.find(d.offset)
+		payload, err := d.frame(i)
+		if err != nil {
+			return n, err
+		}
+		m := copy(dst[n:], payload[d.offset-d.frames[i].start:])
+		n += m
+		d.offset += m
+	}
+	return n, nil
+}
+
+func (d *ChecksumDecorator) Rewind() {
+	d.offset = 0
+}
+
+// seeks to the offset in the content
+func (d *ChecksumDecorator) Seek(offset int) {
+	d.offset = offset
+}
+
+func (d *ChecksumDecorator) Close() error {
+	d.frames = nil
+	d.size = 0
+	d.offset = 0
+	return d.buffer.Close()
+}
+
+// read verified bytes up to the first occurrence of c
+func (d *ChecksumDecorator) ReadBytes(c byte) ([]byte, error) {
+	var b []byte
+	for d.offset < d.size {
+		i := d.find(d.offset)
+		payload, err := d.frame(i)
+		if err != nil {
+			return b, err
+		}
+		rest := payload[d.offset-d.frames[i].start:]
+		if j := bytes.IndexByte(rest, c); j != -1 {
+			b = append(b, rest[:j+1]...)
+			d.offset += j + 1
+			return b, nil
+		}
+		b = append(b, rest...)
+		d.offset += len(rest)
+	}
+	return b, io.EOF
+}
+
+// number of unread bytes
+func (d *ChecksumDecorator) Len() int {
+	return d.size - d.offset
+}
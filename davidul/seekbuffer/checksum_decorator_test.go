@@ -0,0 +1,81 @@
+package seekbuffer
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestChecksumDecorator(t *testing.T) {
+	for _, algorithm := range []ChecksumAlgorithm{ChecksumCRC32C, ChecksumSHA256} {
+		inner := NewEmptySeekBuffer()
+		decorator, _ := NewChecksumDecorator(inner, algorithm)
+		decorator.Write([]byte("abc\n"))
+		decorator.Append([]byte("def"))
+		if len(inner.Bytes()) != 2+7+2*algorithm.size() {
+			t.Errorf("wrapped buffer should hold frames, but got %d bytes", len(inner.Bytes()))
+		}
+		if string(decorator.Bytes()) != "abc\ndef" {
+			t.Errorf("content should be abc def, but got %q", decorator.Bytes())
+		}
+		line, err := decorator.ReadBytes('\n')
+		if err != nil || string(line) != "abc\n" {
+			t.Errorf("line should be abc, but got %q, %v", line, err)
+		}
+		dst := make([]byte, 8)
+		n, _ := decorator.Read(dst)
+		if string(dst[:n]) != "def" {
+			t.Errorf("read should be def, but got %q", dst[:n])
+		}
+		if _, err := decorator.Read(dst); err != io.EOF {
+			t.Errorf("error should be EOF, but got %v", err)
+		}
+	}
+}
+
+func TestChecksumDecorator_Corruption(t *testing.T) {
+	inner := NewEmptySeekBuffer()
+	decorator, _ := NewChecksumDecorator(inner, ChecksumCRC32C)
+	decorator.Write([]byte("abc"))
+	decorator.Write([]byte("def"))
+
+	damaged := inner.BytesCopy()
+	damaged[len(damaged)-6] ^= 0xff
+	loaded, err := NewChecksumDecorator(NewSeekBuffer(damaged), ChecksumCRC32C)
+	if err != nil {
+		t.Fatalf("error should be nil, but got %v", err)
+	}
+	dst := make([]byte, 3)
+	if n, err := loaded.Read(dst); err != nil || string(dst[:n]) != "abc" {
+		t.Errorf("first frame should be intact, but got %q, %v", dst[:n], err)
+	}
+	if _, err := loaded.Read(dst); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("error should be ErrChecksumMismatch, but got %v", err)
+	}
+	if err := loaded.VerifyAll(); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("error should be ErrChecksumMismatch, but got %v", err)
+	}
+	if err := decorator.VerifyAll(); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+}
+
+func TestChecksumDecorator_Truncated(t *testing.T) {
+	inner := NewEmptySeekBuffer()
+	decorator, _ := NewChecksumDecorator(inner, ChecksumSHA256)
+	decorator.Write([]byte("abc"))
+	truncated := inner.Bytes()[:len(inner.Bytes())-1]
+	if _, err := NewChecksumDecorator(NewSeekBuffer(truncated), ChecksumSHA256); err != ErrInvalidEncoding {
+		t.Errorf("error should be ErrInvalidEncoding, but got %v", err)
+	}
+}
+
+func TestChecksumDecorator_OversizedLength(t *testing.T) {
+	for _, n := range []uint64{^uint64(0) - 3, ^uint64(0), 1 << 40} {
+		prefix := binary.AppendUvarint(nil, n)
+		if _, err := NewChecksumDecorator(NewSeekBuffer(prefix), ChecksumCRC32C); err != ErrInvalidEncoding {
+			t.Errorf("length %d should fail with ErrInvalidEncoding, but got %v", n, err)
+		}
+	}
+}
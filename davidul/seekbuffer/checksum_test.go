@@ -0,0 +1,35 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"hash/crc32"
+	"testing"
+)
+
+func TestCRC32(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("hello"))
+	if buffer.CRC32() != crc32.ChecksumIEEE([]byte("hello")) {
+		t.Errorf("crc should match, but got %x", buffer.CRC32())
+	}
+}
+
+func TestSHA256(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("hello"))
+	if buffer.SHA256() != sha256.Sum256([]byte("hello")) {
+		t.Errorf("digest should match, but got %x", buffer.SHA256())
+	}
+}
+
+func TestSum(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("hello"))
+	buffer.Seek(2)
+	full := sha256.Sum256([]byte("hello"))
+	if sum := buffer.Sum(sha256.New()); !bytes.Equal(sum, full[:]) {
+		t.Errorf("sum should match, but got %x", sum)
+	}
+	unread := sha256.Sum256([]byte("llo"))
+	if sum := buffer.SumUnread(sha256.New()); !bytes.Equal(sum, unread[:]) {
+		t.Errorf("sum should match, but got %x", sum)
+	}
+}
@@ -0,0 +1,138 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"io"
+)
+
+// default chunk size of NewChunkedSeekBuffer
+const defaultChunkSize = 4096
+
+// buffer storing its content in fixed-size chunks, appending never
+// copies data already stored
+type ChunkedSeekBuffer struct {
+	// every chunk but the last is full
+	chunks    [][]byte
+	chunkSize int
+	size      int
+	offset    int
+}
+
+var _ SeekableBuffer = (*ChunkedSeekBuffer)(nil)
+
+// empty chunked buffer, chunkSize defaults to 4096 when not positive
+func NewChunkedSeekBuffer(chunkSize int) *ChunkedSeekBuffer {
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+	return &ChunkedSeekBuffer{
+		chunkSize: chunkSize,
+	}
+}
+
+// returns a stitched copy of the content of the buffer
+func (c *ChunkedSeekBuffer) Bytes() []byte {
+	b := make([]byte, 0, c.size)
+	for _, chunk := range c.chunks {
+		b = append(b, chunk...)
+	}
+	return b
+}
+
+// returns a copy of the content of the buffer, same as Bytes
+func (c *ChunkedSeekBuffer) BytesCopy() []byte {
+	return c.Bytes()
+}
+
+// appends content to the buffer
+func (c *ChunkedSeekBuffer) Append(src []byte) error {
+	for len(src) > 0 {
+		if len(c.chunks) == 0 || len(c.chunks[len(c.chunks)-1]) == c.chunkSize {
+			c.chunks = append(c.chunks, make([]byte, 0, c.chunkSize))
+		}
+		last := len(c.chunks) - 1
+		n := min(c.chunkSize-len(c.chunks[last]), len(src))
+		c.chunks[last] = append(c.chunks[last], src[:n]...)
+		c.size += n
+		src = src[n:]
+	}
+	return nil
+}
+
+// writes content to the buffer, alias for Append
+func (c *ChunkedSeekBuffer) Write(src []byte) (int, error) {
+	c.Append(src)
+	return len(src), nil
+}
+
+// reads content from the buffer into dst
+func (c *ChunkedSeekBuffer) Read(dst []byte) (int, error) {
+	if c.offset >= c.size {
+		return 0, io.EOF
+	}
+	n := 0
+	for n < len(dst) && c.offset < c.size {
+		chunk := c.chunks[c.offset/c.chunkSize][c.offset%c.chunkSize:]
+		m := copy(dst[n:], chunk)
+		n += m
+		c.offset += m
+	}
+	return n, nil
+}
+
+// rewinds the buffer to the beginning
+func (c *ChunkedSeekBuffer) Rewind() {
+	c.offset = 0
+}
+
+// seeks to the offset
+func (c *ChunkedSeekBuffer) Seek(offset int) {
+	c.offset = offset
+}
+
+// closes the buffer
+func (c *ChunkedSeekBuffer) Close() error {
+	c.chunks = nil
+	c.size = 0
+	c.offset = 0
+	return nil
+}
+
+// read bytes up to the first occurrence of delim
+func (c *ChunkedSeekBuffer) ReadBytes(delim byte) ([]byte, error) {
+	var b []byte
+	for c.offset < c.size {
+		chunk := c.chunks[c.offset/c.chunkSize][c.offset%c.chunkSize:]
+		if i := bytes.IndexByte(chunk, delim); i != -1 {
+			b = append(b, chunk[:i+1]...)
+			c.offset += i + 1
+			return b, nil
+		}
+		b = append(b, chunk...)
+		c.offset += len(chunk)
+	}
+	return b, io.EOF
+}
+
+// returns the number of unread bytes
+func (c *ChunkedSeekBuffer) Len() int {
+	return c.size - c.offset
+}
+
+// writes the unread content to w chunk by chunk and advances the offset
+func (c *ChunkedSeekBuffer) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for c.offset < c.size {
+		chunk := c.chunks[c.offset/c.chunkSize][c.offset%c.chunkSize:]
+		n, err := w.Write(chunk)
+		total += int64(n)
+		c.offset += n
+		if err != nil {
+			return total, err
+		}
+		if n < len(chunk) {
+			return total, io.ErrShortWrite
+		}
+	}
+	return total, nil
+}
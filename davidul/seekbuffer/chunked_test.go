@@ -0,0 +1,94 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestChunkedAppend(t *testing.T) {
+	buffer := NewChunkedSeekBuffer(4)
+	buffer.Append([]byte{1, 2, 3})
+	first := buffer.chunks[0]
+	buffer.Append([]byte{4, 5, 6, 7, 8, 9})
+	if len(buffer.chunks) != 3 {
+		t.Errorf("chunks should be 3, but got %d", len(buffer.chunks))
+	}
+	if &first[0] != &buffer.chunks[0][0] {
+		t.Errorf("first chunk should not be copied")
+	}
+	if !bytes.Equal(buffer.Bytes(), []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("content should match, but got %v", buffer.Bytes())
+	}
+	if buffer.Len() != 9 {
+		t.Errorf("len should be 9, but got %d", buffer.Len())
+	}
+}
+
+func TestChunkedRead(t *testing.T) {
+	buffer := NewChunkedSeekBuffer(4)
+	buffer.Write([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	buffer.Seek(3)
+	dst := make([]byte, 4)
+	n, err := buffer.Read(dst)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if n != 4 {
+		t.Errorf("n should be 4, but got %d", n)
+	}
+	if !bytes.Equal(dst, []byte{4, 5, 6, 7}) {
+		t.Errorf("dst should be 4..7, but got %v", dst)
+	}
+	n, _ = buffer.Read(dst)
+	if n != 2 {
+		t.Errorf("n should be 2, but got %d", n)
+	}
+	if _, err := buffer.Read(dst); err != io.EOF {
+		t.Errorf("error should be EOF, but got %v", err)
+	}
+	buffer.Rewind()
+	if buffer.Len() != 9 {
+		t.Errorf("len should be 9, but got %d", buffer.Len())
+	}
+}
+
+func TestChunkedReadBytes(t *testing.T) {
+	buffer := NewChunkedSeekBuffer(2)
+	buffer.Write([]byte("abc\ndef"))
+	line, err := buffer.ReadBytes('\n')
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if string(line) != "abc\n" {
+		t.Errorf("line should be abc, but got %q", line)
+	}
+	line, err = buffer.ReadBytes('\n')
+	if err != io.EOF {
+		t.Errorf("error should be EOF, but got %v", err)
+	}
+	if string(line) != "def" {
+		t.Errorf("line should be def, but got %q", line)
+	}
+}
+
+func TestChunkedWriteTo(t *testing.T) {
+	buffer := NewChunkedSeekBuffer(2)
+	buffer.Write([]byte("hello"))
+	buffer.Seek(1)
+	var w bytes.Buffer
+	n, err := buffer.WriteTo(&w)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if n != 4 || w.String() != "ello" {
+		t.Errorf("should write ello, but got %q", w.String())
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("len should be 0, but got %d", buffer.Len())
+	}
+	buffer.Close()
+	if len(buffer.Bytes()) != 0 {
+		t.Errorf("buffer should be empty after close")
+	}
+}
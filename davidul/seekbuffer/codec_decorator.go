@@ -0,0 +1,78 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// text encoding used by CodecDecorator
+type TextEncoding int
+
+const (
+	// standard base64 with padding
+	Base64Encoding TextEncoding = iota
+	// lower case hex
+	HexEncoding
+)
+
+// decorator storing every write as one base64 or hex encoded line in the
+// wrapped buffer, reads return the decoded content
+type CodecDecorator struct {
+	*TransformDecorator
+}
+
+var _ SeekableBuffer = (*CodecDecorator)(nil)
+
+// wraps buffer encoding writes with encoding
+func NewCodecDecorator(buffer SeekableBuffer, encoding TextEncoding) *CodecDecorator {
+	encode, decode := base64Line, fromBase64Lines
+	if encoding == HexEncoding {
+		encode, decode = hexLine, fromHexLines
+	}
+	return &CodecDecorator{
+		TransformDecorator: NewTransformDecorator(buffer, encode, decode),
+	}
+}
+
+func base64Line(src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+	b := base64.StdEncoding.AppendEncode(nil, src)
+	return append(b, '\n'), nil
+}
+
+func hexLine(src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+	b := hex.AppendEncode(nil, src)
+	return append(b, '\n'), nil
+}
+
+// decodes every non-empty line with decode
+func decodeLines(src []byte, decode func(dst, src []byte) ([]byte, error)) ([]byte, error) {
+	var out []byte
+	for len(src) > 0 {
+		line := src
+		if i := bytes.IndexByte(src, '\n'); i != -1 {
+			line, src = src[:i], src[i+1:]
+		} else {
+			src = nil
+		}
+		var err error
+		if out, err = decode(out, bytes.TrimSuffix(line, []byte("\r"))); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func fromBase64Lines(src []byte) ([]byte, error) {
+	return decodeLines(src, base64.StdEncoding.AppendDecode)
+}
+
+func fromHexLines(src []byte) ([]byte, error) {
+	return decodeLines(src, hex.AppendDecode)
+}
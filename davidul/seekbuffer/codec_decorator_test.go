@@ -0,0 +1,45 @@
+package seekbuffer
+
+import (
+	"testing"
+)
+
+func TestCodecDecorator_Base64(t *testing.T) {
+	inner := NewEmptySeekBuffer()
+	decorator := NewCodecDecorator(inner, Base64Encoding)
+	decorator.Write([]byte{0, 1, 2})
+	decorator.Append([]byte("a\nb"))
+	if string(inner.Bytes()) != "AAEC\nYQpi\n" {
+		t.Errorf("stored content should be base64 lines, but got %q", inner.Bytes())
+	}
+	if string(decorator.Bytes()) != "\x00\x01\x02a\nb" {
+		t.Errorf("content should be decoded, but got %q", decorator.Bytes())
+	}
+	decorator.Seek(3)
+	line, _ := decorator.ReadBytes('\n')
+	if string(line) != "a\n" {
+		t.Errorf("line should be a, but got %q", line)
+	}
+}
+
+func TestCodecDecorator_Hex(t *testing.T) {
+	inner := NewEmptySeekBuffer()
+	decorator := NewCodecDecorator(inner, HexEncoding)
+	decorator.Write([]byte{0xde, 0xad})
+	decorator.Write([]byte{0xbe, 0xef})
+	if string(inner.Bytes()) != "dead\nbeef\n" {
+		t.Errorf("stored content should be hex lines, but got %q", inner.Bytes())
+	}
+	dst := make([]byte, 4)
+	n, _ := decorator.Read(dst)
+	if n != 4 || dst[3] != 0xef {
+		t.Errorf("read should be deadbeef, but got %x", dst[:n])
+	}
+}
+
+func TestCodecDecorator_Invalid(t *testing.T) {
+	decorator := NewCodecDecorator(NewSeekBuffer([]byte("zz\n")), HexEncoding)
+	if _, err := decorator.Read(make([]byte, 1)); err == nil {
+		t.Errorf("error should not be nil")
+	}
+}
@@ -0,0 +1,284 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"sort"
+)
+
+// compresses and decompresses independent blocks of data
+type Codec interface {
+	// appends the compressed form of src to dst
+	Compress(dst, src []byte) ([]byte, error)
+	// returns the data compressed by Compress
+	Decompress(src []byte) ([]byte, error)
+}
+
+// gzip codec with the given compression level
+type GzipCodec struct {
+	Level int
+}
+
+func (c GzipCodec) Compress(dst, src []byte) ([]byte, error) {
+	w := bytes.NewBuffer(dst)
+	zw, err := gzip.NewWriterLevel(w, c.level())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(src); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+func (c GzipCodec) Decompress(src []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+func (c GzipCodec) level() int {
+	if c.Level == 0 {
+		return gzip.DefaultCompression
+	}
+	return c.Level
+}
+
+// raw DEFLATE codec with the given compression level
+type FlateCodec struct {
+	Level int
+}
+
+func (c FlateCodec) Compress(dst, src []byte) ([]byte, error) {
+	w := bytes.NewBuffer(dst)
+	level := c.Level
+	if level == 0 {
+		level = flate.DefaultCompression
+	}
+	fw, err := flate.NewWriter(w, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fw.Write(src); err != nil {
+		return nil, err
+	}
+	if err := fw.Close(); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+func (c FlateCodec) Decompress(src []byte) ([]byte, error) {
+	fr := flate.NewReader(bytes.NewReader(src))
+	defer fr.Close()
+	return io.ReadAll(fr)
+}
+
+// compressed block stored in the wrapped buffer
+type compressedFrame struct {
+	// offset of the first decompressed byte
+	start int
+	// decompressed size
+	size int
+	// position of the compressed payload in the wrapped buffer
+	pos, n int
+}
+
+// decorator storing data compressed in the wrapped buffer, every write is
+// compressed as an independent frame prefixed by its uvarint length
+type CompressionDecorator struct {
+	buffer SeekableBuffer
+	codec  Codec
+	frames []compressedFrame
+	size   int
+	offset int
+	// last decompressed frame
+	cached      int
+	cachedBytes []byte
+}
+
+var _ SeekableBuffer = (*CompressionDecorator)(nil)
+
+// wraps buffer, frames already stored in it are indexed so a buffer
+// loaded from a file can be read back
+func NewCompressionDecorator(buffer SeekableBuffer, codec Codec) (*CompressionDecorator, error) {
+	d := &CompressionDecorator{
+		buffer: buffer,
+		codec:  codec,
+		cached: -1,
+	}
+	content := buffer.Bytes()
+	for pos := 0; pos < len(content); {
+		n, k := binary.Uvarint(content[pos:])
+		if k <= 0 || uint64(len(content)-pos-k) < n {
+			return nil, ErrInvalidEncoding
+		}
+		data, err := codec.Decompress(content[pos+k : pos+k+int(n)])
+		if err != nil {
+			return nil, err
+		}
+		d.frames = append(d.frames, compressedFrame{start: d.size, size: len(data), pos: pos + k, n: int(n)})
+		d.size += len(data)
+		pos += k + int(n)
+	}
+	return d, nil
+}
+
+// number of bytes held by the wrapped buffer
+func (d *CompressionDecorator) CompressedLen() int {
+	return len(d.buffer.Bytes())
+}
+
+// compressed size divided by the decompressed size, 0 when empty
+func (d *CompressionDecorator) Ratio() float64 {
+	if d.size == 0 {
+		return 0
+	}
+	return float64(d.CompressedLen()) / float64(d.size)
+}
+
+// returns the decompressed content of frame i
+func (d *CompressionDecorator) frame(i int) ([]byte, error) {
+	if i == d.cached {
+		return d.cachedBytes, nil
+	}
+	f := d.frames[i]
+	data, err := d.codec.Decompress(d.buffer.Bytes()[f.pos : f.pos+f.n])
+	if err != nil {
+		return nil, err
+	}
+	d.cached, d.cachedBytes = i, data
+	return data, nil
+}
+
+// index of the frame holding offset
+func (d *CompressionDecorator) find(offset int) int {
+	return sort.Search(len(d.frames), func(i int) bool {
+		return d.frames[i].start+d.frames[i].size > offset
+	})
+}
+
+// returns the decompressed content or the error of the first frame
+// which cannot be decompressed
+func (d *CompressionDecorator) Content() ([]byte, error) {
+	b := make([]byte, 0, d.size)
+	for i := range d.frames {
+		data, err := d.frame(i)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, data...)
+	}
+	return b, nil
+}
+
+// returns the decompressed content, nil when a frame cannot be
+// decompressed so damaged content is never mistaken for a shorter one,
+// Content reports the error
+func (d *CompressionDecorator) Bytes() []byte {
+	b, err := d.Content()
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// compresses src and appends it to the wrapped buffer as a new frame
+func (d *CompressionDecorator) Append(src []byte) error {
+	if len(src) == 0 {
+		return nil
+	}
+	compressed, err := d.codec.Compress(nil, src)
+	if err != nil {
+		return err
+	}
+	frame := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(compressed)), uint64(len(compressed)))
+	k := len(frame)
+	frame = append(frame, compressed...)
+	pos := len(d.buffer.Bytes())
+	if err := d.buffer.Append(frame); err != nil {
+		return err
+	}
+	d.frames = append(d.frames, compressedFrame{start: d.size, size: len(src), pos: pos + k, n: len(compressed)})
+	d.size += len(src)
+	return nil
+}
+
+// compresses src and writes it to the wrapped buffer, alias for Append
+func (d *CompressionDecorator) Write(src []byte) (int, error) {
+	if err := d.Append(src); err != nil {
+		return 0, err
+	}
+	return len(src), nil
+}
+
+// reads decompressed content into dst
+func (d *CompressionDecorator) Read(dst []byte) (int, error) {
+	if d.offset >= d.size {
+		return 0, io.EOF
+	}
+	n := 0
+	for n < len(dst) && d.offset < d.size {
+		i := d.find(d.offset)
+		data, err := d.frame(i)
+		if err != nil {
+			return n, err
+		}
+		m := copy(dst[n:], data[d.offset-d.frames[i].start:])
+		n += m
+		d.offset += m
+	}
+	return n, nil
+}
+
+func (d *CompressionDecorator) Rewind() {
+	d.offset = 0
+}
+
+// seeks to the offset in the decompressed content
+func (d *CompressionDecorator) Seek(offset int) {
+	d.offset = offset
+}
+
+func (d *CompressionDecorator) Close() error {
+	d.frames = nil
+	d.size = 0
+	d.offset = 0
+	d.cached, d.cachedBytes = -1, nil
+	return d.buffer.Close()
+}
+
+// read decompressed bytes up to the first occurrence of c
+func (d *CompressionDecorator) ReadBytes(c byte) ([]byte, error) {
+	var b []byte
+	for d.offset < d.size {
+		i := d.find(d.offset)
+		data, err := d.frame(i)
+		if err != nil {
+			return b, err
+		}
+		rest := data[d.offset-d.frames[i].start:]
+		if j := bytes.IndexByte(rest, c); j != -1 {
+			b = append(b, rest[:j+1]...)
+			d.offset += j + 1
+			return b, nil
+		}
+		b = append(b, rest...)
+		d.offset += len(rest)
+	}
+	return b, io.EOF
+}
+
+// number of unread decompressed bytes
+func (d *CompressionDecorator) Len() int {
+	return d.size - d.offset
+}
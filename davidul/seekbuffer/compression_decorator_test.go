@@ -0,0 +1,88 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestCompressionDecorator(t *testing.T) {
+	inner := NewEmptySeekBuffer()
+	decorator, err := NewCompressionDecorator(inner, GzipCodec{})
+	if err != nil {
+		t.Fatalf("error should be nil, but got %v", err)
+	}
+	text := bytes.Repeat([]byte("hello world\n"), 100)
+	decorator.Write(text)
+	decorator.Append([]byte("tail"))
+	if bytes.Contains(inner.Bytes(), []byte("hello")) {
+		t.Errorf("wrapped buffer should hold compressed data only")
+	}
+	if decorator.Len() != len(text)+4 {
+		t.Errorf("len should be %d, but got %d", len(text)+4, decorator.Len())
+	}
+	if !bytes.Equal(decorator.Bytes(), append(append([]byte(nil), text...), "tail"...)) {
+		t.Errorf("content should round-trip")
+	}
+	if decorator.Ratio() >= 0.5 {
+		t.Errorf("ratio should be below 0.5, but got %f", decorator.Ratio())
+	}
+	if decorator.CompressedLen() != len(inner.Bytes()) {
+		t.Errorf("compressed len should be %d, but got %d", len(inner.Bytes()), decorator.CompressedLen())
+	}
+}
+
+func TestCompressionDecorator_Read(t *testing.T) {
+	decorator, _ := NewCompressionDecorator(NewEmptySeekBuffer(), FlateCodec{})
+	decorator.Write([]byte("abc"))
+	decorator.Write([]byte("def\ngh"))
+	decorator.Seek(2)
+	dst := make([]byte, 3)
+	n, err := decorator.Read(dst)
+	if err != nil || string(dst[:n]) != "cde" {
+		t.Errorf("read should be cde, but got %q, %v", dst[:n], err)
+	}
+	line, err := decorator.ReadBytes('\n')
+	if err != nil || string(line) != "f\n" {
+		t.Errorf("line should be f, but got %q, %v", line, err)
+	}
+	line, err = decorator.ReadBytes('\n')
+	if err != io.EOF || string(line) != "gh" {
+		t.Errorf("line should be gh with EOF, but got %q, %v", line, err)
+	}
+	if _, err := decorator.Read(dst); err != io.EOF {
+		t.Errorf("error should be EOF, but got %v", err)
+	}
+}
+
+func TestCompressionDecorator_Reopen(t *testing.T) {
+	inner := NewEmptySeekBuffer()
+	decorator, _ := NewCompressionDecorator(inner, GzipCodec{})
+	decorator.Write([]byte("abc"))
+	decorator.Write([]byte("def"))
+
+	reopened, err := NewCompressionDecorator(NewSeekBuffer(inner.Bytes()), GzipCodec{})
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if string(reopened.Bytes()) != "abcdef" {
+		t.Errorf("content should be abcdef, but got %q", reopened.Bytes())
+	}
+	if _, err := NewCompressionDecorator(NewSeekBuffer([]byte{10, 1}), GzipCodec{}); err != ErrInvalidEncoding {
+		t.Errorf("error should be ErrInvalidEncoding, but got %v", err)
+	}
+}
+
+func TestCompressionDecorator_Damaged(t *testing.T) {
+	inner := NewEmptySeekBuffer()
+	decorator, _ := NewCompressionDecorator(inner, GzipCodec{})
+	decorator.Write([]byte("first"))
+	decorator.Write([]byte("second"))
+	inner.Bytes()[len(inner.Bytes())-6] ^= 0xff
+	if _, err := decorator.Content(); err == nil {
+		t.Errorf("content should report the damaged frame")
+	}
+	if b := decorator.Bytes(); b != nil {
+		t.Errorf("bytes should be nil for damaged content, but got %q", b)
+	}
+}
@@ -0,0 +1,37 @@
+package seekbuffer
+
+import (
+	"context"
+)
+
+// reads like Read unless ctx is already done
+func (s *SeekBuffer) ReadContext(ctx context.Context, dst []byte) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return s.Read(dst)
+}
+
+// writes like Write unless ctx is already done
+func (s *SeekBuffer) WriteContext(ctx context.Context, src []byte) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return s.Write(src)
+}
+
+// reads like Read unless ctx is already done
+func (c *ChunkedSeekBuffer) ReadContext(ctx context.Context, dst []byte) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.Read(dst)
+}
+
+// writes like Write unless ctx is already done
+func (c *ChunkedSeekBuffer) WriteContext(ctx context.Context, src []byte) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.Write(src)
+}
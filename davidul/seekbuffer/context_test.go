@@ -0,0 +1,46 @@
+package seekbuffer
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReadContext(t *testing.T) {
+	buffer := NewSeekBuffer([]byte{1, 2, 3})
+	dst := make([]byte, 2)
+	n, err := buffer.ReadContext(context.Background(), dst)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if n != 2 {
+		t.Errorf("n should be 2, but got %d", n)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	n, err = buffer.ReadContext(ctx, dst)
+	if err != context.Canceled {
+		t.Errorf("error should be Canceled, but got %v", err)
+	}
+	if n != 0 || buffer.offset != 2 {
+		t.Errorf("nothing should be read, but got %d", n)
+	}
+}
+
+func TestWriteContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var buffers = []ContextBuffer{NewEmptySeekBuffer(), NewChunkedSeekBuffer(2)}
+	for _, buffer := range buffers {
+		if _, err := buffer.WriteContext(ctx, []byte{1, 2, 3}); err != nil {
+			t.Errorf("error should be nil, but got %v", err)
+		}
+	}
+	cancel()
+	for _, buffer := range buffers {
+		if _, err := buffer.WriteContext(ctx, []byte{4}); err != context.Canceled {
+			t.Errorf("error should be Canceled, but got %v", err)
+		}
+		if buffer.Len() != 3 {
+			t.Errorf("len should be 3, but got %d", buffer.Len())
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"crypto/sha256"
+)
+
+// configures DedupDecorator
+type DedupConfig struct {
+	// splits writes into records ending with Delimiter, otherwise every
+	// write is one record
+	Delimited bool
+	Delimiter byte
+	// number of recent records remembered, 0 remembers all of them
+	Window int
+}
+
+// counters of DedupDecorator
+type DedupStats struct {
+	// records seen
+	Records int
+	// records dropped as duplicates
+	Duplicates int
+	// bytes dropped as duplicates
+	BytesDropped int
+}
+
+// decorator silently dropping records already seen within a window
+type DedupDecorator struct {
+	buffer SeekableBuffer
+	config DedupConfig
+	seen   map[[sha256.Size]byte]int
+	// hashes in arrival order, used to evict the oldest record
+	order   [][sha256.Size]byte
+	pending []byte
+	stats   DedupStats
+}
+
+var _ SeekableBuffer = (*DedupDecorator)(nil)
+
+// wraps buffer without any records seen
+func NewDedupDecorator(buffer SeekableBuffer, config DedupConfig) *DedupDecorator {
+	if config.Window < 0 {
+		config.Window = 0
+	}
+	return &DedupDecorator{
+		buffer: buffer,
+		config: config,
+		seen:   map[[sha256.Size]byte]int{},
+	}
+}
+
+// returns the counters
+func (d *DedupDecorator) Stats() DedupStats {
+	return d.stats
+}
+
+// appends record unless it is a duplicate
+func (d *DedupDecorator) record(record []byte) error {
+	d.stats.Records++
+	h := sha256.Sum256(record)
+	if d.seen[h] > 0 {
+		d.stats.Duplicates++
+		d.stats.BytesDropped += len(record)
+		return nil
+	}
+	if err := d.buffer.Append(record); err != nil {
+		return err
+	}
+	d.seen[h]++
+	d.order = append(d.order, h)
+	if d.config.Window > 0 && len(d.order) > d.config.Window {
+		oldest := d.order[0]
+		d.order = d.order[1:]
+		if d.seen[oldest]--; d.seen[oldest] == 0 {
+			delete(d.seen, oldest)
+		}
+	}
+	return nil
+}
+
+func (d *DedupDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+// appends the records of src which were not seen yet, with a delimiter
+// an unterminated tail is held back until the next write completes it
+func (d *DedupDecorator) Append(src []byte) error {
+	if !d.config.Delimited {
+		if len(src) == 0 {
+			return nil
+		}
+		return d.record(src)
+	}
+	data := append(d.pending, src...)
+	d.pending = nil
+	for {
+		i := bytes.IndexByte(data, d.config.Delimiter)
+		if i == -1 {
+			break
+		}
+		if err := d.record(data[:i+1]); err != nil {
+			d.pending = append([]byte(nil), data...)
+			return err
+		}
+		data = data[i+1:]
+	}
+	if len(data) > 0 {
+		d.pending = append([]byte(nil), data...)
+	}
+	return nil
+}
+
+// writes src dropping duplicate records, reports len(src) on success
+func (d *DedupDecorator) Write(src []byte) (int, error) {
+	if err := d.Append(src); err != nil {
+		return 0, err
+	}
+	return len(src), nil
+}
+
+// appends the held back tail as a final record
+func (d *DedupDecorator) Flush() error {
+	if len(d.pending) == 0 {
+		return nil
+	}
+	record := d.pending
+	d.pending = nil
+	return d.record(record)
+}
+
+func (d *DedupDecorator) Read(dst []byte) (int, error) {
+	return d.buffer.Read(dst)
+}
+
+func (d *DedupDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *DedupDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+// drops the held back tail and the remembered records and closes the buffer
+func (d *DedupDecorator) Close() error {
+	d.pending = nil
+	d.seen = map[[sha256.Size]byte]int{}
+	d.order = nil
+	return d.buffer.Close()
+}
+
+func (d *DedupDecorator) ReadBytes(c byte) ([]byte, error) {
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *DedupDecorator) Len() int {
+	return d.buffer.Len()
+}
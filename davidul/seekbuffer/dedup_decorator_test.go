@@ -0,0 +1,48 @@
+package seekbuffer
+
+import (
+	"testing"
+)
+
+func TestDedupDecorator_PerWrite(t *testing.T) {
+	decorator := NewDedupDecorator(NewEmptySeekBuffer(), DedupConfig{})
+	decorator.Write([]byte("a"))
+	decorator.Write([]byte("b"))
+	n, err := decorator.Write([]byte("a"))
+	if err != nil || n != 1 {
+		t.Errorf("duplicate write should succeed, but got %d, %v", n, err)
+	}
+	if string(decorator.Bytes()) != "ab" {
+		t.Errorf("content should be ab, but got %q", decorator.Bytes())
+	}
+	stats := decorator.Stats()
+	if stats.Records != 3 || stats.Duplicates != 1 || stats.BytesDropped != 1 {
+		t.Errorf("stats should count one duplicate, but got %+v", stats)
+	}
+}
+
+func TestDedupDecorator_Delimited(t *testing.T) {
+	decorator := NewDedupDecorator(NewEmptySeekBuffer(), DedupConfig{Delimited: true, Delimiter: '\n'})
+	decorator.Write([]byte("x\ny\nx"))
+	if string(decorator.Bytes()) != "x\ny\n" {
+		t.Errorf("content should be x y, but got %q", decorator.Bytes())
+	}
+	decorator.Write([]byte("\nz"))
+	if string(decorator.Bytes()) != "x\ny\n" {
+		t.Errorf("completed duplicate should be dropped, but got %q", decorator.Bytes())
+	}
+	decorator.Flush()
+	if string(decorator.Bytes()) != "x\ny\nz" {
+		t.Errorf("flush should append z, but got %q", decorator.Bytes())
+	}
+}
+
+func TestDedupDecorator_Window(t *testing.T) {
+	decorator := NewDedupDecorator(NewEmptySeekBuffer(), DedupConfig{Window: 2})
+	for _, r := range []string{"a", "b", "c", "a", "c"} {
+		decorator.Write([]byte(r))
+	}
+	if string(decorator.Bytes()) != "abca" {
+		t.Errorf("a should leave the window, but got %q", decorator.Bytes())
+	}
+}
@@ -0,0 +1,59 @@
+package seekbuffer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// controls the layout of Dump
+type DumpOptions struct {
+	// bytes per line, defaults to 16
+	Width int
+}
+
+// writes a hex and ASCII dump of the whole buffer to w, the byte at the
+// current offset is marked with '>'
+func (s *SeekBuffer) Dump(w io.Writer, opts DumpOptions) error {
+	width := opts.Width
+	if width <= 0 {
+		width = 16
+	}
+	bw := bufio.NewWriter(w)
+	for line := 0; line < len(s.buffer); line += width {
+		fmt.Fprintf(bw, "%08x ", line)
+		for i := line; i < line+width; i++ {
+			if i >= len(s.buffer) {
+				bw.WriteString("   ")
+				continue
+			}
+			if i == s.offset {
+				bw.WriteByte('>')
+			} else {
+				bw.WriteByte(' ')
+			}
+			fmt.Fprintf(bw, "%02x", s.buffer[i])
+		}
+		bw.WriteString("  |")
+		for i := line; i < line+width && i < len(s.buffer); i++ {
+			c := s.buffer[i]
+			if c < 0x20 || c > 0x7e {
+				c = '.'
+			}
+			bw.WriteByte(c)
+		}
+		bw.WriteString("|\n")
+	}
+	if s.offset >= len(s.buffer) {
+		fmt.Fprintf(bw, "%08x >\n", s.offset)
+	}
+	return bw.Flush()
+}
+
+// returns the dump of the buffer with default options
+func (s *SeekBuffer) DumpString() string {
+	var sb strings.Builder
+	s.Dump(&sb, DumpOptions{})
+	return sb.String()
+}
@@ -0,0 +1,30 @@
+package seekbuffer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("hello\n"))
+	buffer.Seek(1)
+	var sb strings.Builder
+	if err := buffer.Dump(&sb, DumpOptions{Width: 4}); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	expected := "00000000  68>65 6c 6c  |hell|\n" +
+		"00000004  6f 0a        |o.|\n"
+	if sb.String() != expected {
+		t.Errorf("dump should be %q, but got %q", expected, sb.String())
+	}
+}
+
+func TestDumpString_End(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("hi"))
+	buffer.Seek(2)
+	expected := "00000000  68 69                                            |hi|\n" +
+		"00000002 >\n"
+	if buffer.DumpString() != expected {
+		t.Errorf("dump should be %q, but got %q", expected, buffer.DumpString())
+	}
+}
@@ -0,0 +1,205 @@
+package seekbuffer
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// configures how files are created by the file persistence methods
+type FileOption func(*fileOptions)
+
+type fileOptions struct {
+	mode      os.FileMode
+	mkdirAll  bool
+	exclusive bool
+}
+
+func newFileOptions(opts []FileOption) *fileOptions {
+	o := &fileOptions{mode: 0644}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// permissions of a newly created file, defaults to 0644, the umask is
+// applied and an existing file keeps its permissions
+func WithFileMode(mode os.FileMode) FileOption {
+	return func(o *fileOptions) {
+		o.mode = mode.Perm()
+	}
+}
+
+// creates missing parent directories
+func WithParentDirs() FileOption {
+	return func(o *fileOptions) {
+		o.mkdirAll = true
+	}
+}
+
+// fails with os.ErrExist when the target file already exists
+func WithExclusive() FileOption {
+	return func(o *fileOptions) {
+		o.exclusive = true
+	}
+}
+
+// prepares the directory of filename
+func (o *fileOptions) prepare(filename string) error {
+	if !o.mkdirAll {
+		return nil
+	}
+	return os.MkdirAll(filepath.Dir(filename), 0755)
+}
+
+// opens filename with the given flags honoring the options
+func (o *fileOptions) open(filename string, flag int) (*os.File, error) {
+	if err := o.prepare(filename); err != nil {
+		return nil, err
+	}
+	if o.exclusive {
+		flag |= os.O_EXCL
+	}
+	return os.OpenFile(filename, flag|os.O_CREATE|os.O_WRONLY, o.mode)
+}
+
+// writes data into filename opened with flag
+func writeFile(filename string, data []byte, flag int, opts []FileOption) error {
+	f, err := newFileOptions(opts).open(filename, flag)
+	if err != nil {
+		return err
+	}
+	_, err = writeChunks(f, data)
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	return err
+}
+
+// buffer with the content of filename, the storage is sized upfront
+// from the file size so large files are read without regrowing
+func NewSeekBufferFromFile(filename string) (*SeekBuffer, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := int(info.Size())
+	b := NewSeekBufferWithCapacity(size)
+	n, err := io.ReadFull(f, b.buffer[:size])
+	b.buffer = b.buffer[:n]
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	// the file may have grown since stat
+	rest, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	b.buffer = append(b.buffer, rest...)
+	return b, nil
+}
+
+// writes the whole buffer to filename replacing its content
+func (s *SeekBuffer) SaveToFile(filename string, opts ...FileOption) error {
+	return writeFile(filename, s.buffer, os.O_TRUNC, opts)
+}
+
+// appends the whole buffer to filename
+func (s *SeekBuffer) AppendToFile(filename string, opts ...FileOption) error {
+	return writeFile(filename, s.buffer, os.O_APPEND, opts)
+}
+
+// appends the unread content to filename, the offset is not changed
+func (s *SeekBuffer) AppendUnreadToFile(filename string, opts ...FileOption) error {
+	return writeFile(filename, s.unread(), os.O_APPEND, opts)
+}
+
+// writes the whole buffer to filename so that readers see either the old
+// or the new content, never a partial write; the content goes to a temp
+// file in the same directory which is synced and renamed over the target
+func (s *SeekBuffer) SaveToFileAtomic(filename string, opts ...FileOption) error {
+	o := newFileOptions(opts)
+	if err := o.prepare(filename); err != nil {
+		return err
+	}
+	dir := filepath.Dir(filename)
+	// an existing target keeps its permissions, a new one gets the mode
+	// minus the umask like with SaveToFile
+	mode, keep := o.mode, false
+	if info, err := os.Stat(filename); err == nil {
+		mode, keep = info.Mode().Perm(), true
+	}
+	tmp, err := createTemp(dir, "."+filepath.Base(filename)+".tmp", mode)
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmpName)
+		}
+	}()
+
+	if _, err = s.SaveToWriter(tmp); err != nil {
+		return err
+	}
+	if keep {
+		// the umask may have narrowed the mode on creation
+		if err = tmp.Chmod(mode); err != nil {
+			return err
+		}
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if o.exclusive {
+		// a hard link fails instead of replacing an existing target
+		if err = os.Link(tmpName, filename); err != nil {
+			if errors.Is(err, os.ErrExist) {
+				err = &os.PathError{Op: "open", Path: filename, Err: os.ErrExist}
+			}
+			return err
+		}
+		os.Remove(tmpName)
+	} else if err = os.Rename(tmpName, filename); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// creates a new file in dir named prefix followed by a random suffix, the
+// umask applies to mode as for any created file
+func createTemp(dir, prefix string, mode os.FileMode) (*os.File, error) {
+	for {
+		name := filepath.Join(dir, prefix+strconv.FormatUint(rand.Uint64(), 36))
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, mode)
+		if !errors.Is(err, os.ErrExist) {
+			return f, err
+		}
+	}
+}
+
+// flushes directory metadata so a rename survives a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	// not every platform supports syncing a directory
+	d.Sync()
+	return nil
+}
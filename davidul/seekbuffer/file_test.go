@@ -0,0 +1,178 @@
+package seekbuffer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveToFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "out.bin")
+	os.WriteFile(filename, []byte("old content"), 0644)
+
+	buffer := NewSeekBuffer([]byte("new"))
+	if err := buffer.SaveToFileAtomic(filename); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if string(content) != "new" {
+		t.Errorf("content should be new, but got %q", content)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory should hold 1 file, but got %d", len(entries))
+	}
+}
+
+func TestSaveToFileAtomic_MissingDir(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "missing", "out.bin")
+	buffer := NewSeekBuffer([]byte("new"))
+	if err := buffer.SaveToFileAtomic(filename); err == nil {
+		t.Errorf("error should not be nil")
+	}
+}
+
+func TestSaveToFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "out.bin")
+	os.WriteFile(filename, []byte("old content"), 0644)
+	buffer := NewSeekBuffer([]byte("new"))
+	if err := buffer.SaveToFile(filename); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	content, _ := os.ReadFile(filename)
+	if string(content) != "new" {
+		t.Errorf("content should be new, but got %q", content)
+	}
+}
+
+func TestAppendToFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "out.bin")
+	buffer := NewSeekBuffer([]byte("abc"))
+	buffer.AppendToFile(filename)
+	buffer.Seek(1)
+	if err := buffer.AppendUnreadToFile(filename); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if buffer.offset != 1 {
+		t.Errorf("offset should be 1, but got %d", buffer.offset)
+	}
+	content, _ := os.ReadFile(filename)
+	if string(content) != "abcbc" {
+		t.Errorf("content should be abcbc, but got %q", content)
+	}
+}
+
+func TestFileOptions(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "a", "b", "out.bin")
+	buffer := NewSeekBuffer([]byte("abc"))
+	if err := buffer.SaveToFile(filename); err == nil {
+		t.Errorf("error should not be nil")
+	}
+	if err := buffer.SaveToFile(filename, WithParentDirs(), WithFileMode(0600)); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	info, _ := os.Stat(filename)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode should be 0600, but got %v", info.Mode().Perm())
+	}
+	if err := buffer.SaveToFile(filename, WithExclusive()); !errors.Is(err, os.ErrExist) {
+		t.Errorf("error should be ErrExist, but got %v", err)
+	}
+	if err := buffer.SaveToFileAtomic(filename, WithExclusive()); !errors.Is(err, os.ErrExist) {
+		t.Errorf("error should be ErrExist, but got %v", err)
+	}
+}
+
+func TestSaveToFileAtomic_Options(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "a", "out.bin")
+	buffer := NewSeekBuffer([]byte("abc"))
+	err := buffer.SaveToFileAtomic(filename, WithParentDirs(), WithFileMode(0640), WithExclusive())
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	info, _ := os.Stat(filename)
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("mode should be 0640, but got %v", info.Mode().Perm())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(filename))
+	if len(entries) != 1 {
+		t.Errorf("directory should hold 1 file, but got %d", len(entries))
+	}
+}
+
+func TestNewSeekBufferFromFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "in.bin")
+	os.WriteFile(filename, []byte("hello"), 0644)
+	buffer, err := NewSeekBufferFromFile(filename)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if buffer.String() != "hello" {
+		t.Errorf("content should be hello, but got %q", buffer.String())
+	}
+	if cap(buffer.buffer) != 5 {
+		t.Errorf("cap should be 5, but got %d", cap(buffer.buffer))
+	}
+	if _, err := NewSeekBufferFromFile(filename + ".missing"); err == nil {
+		t.Errorf("error should not be nil")
+	}
+}
+
+func TestSaveToFileAtomic_KeepsMode(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "out.bin")
+	os.WriteFile(filename, []byte("old"), 0600)
+	os.Chmod(filename, 0600)
+	if err := NewSeekBuffer([]byte("new")).SaveToFileAtomic(filename); err != nil {
+		t.Fatalf("error should be nil, but got %v", err)
+	}
+	info, _ := os.Stat(filename)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("existing mode 0600 should be kept, but got %v", info.Mode().Perm())
+	}
+}
+
+func TestFileOptions_DefaultMode(t *testing.T) {
+	dir := t.TempDir()
+	plain, atomic := filepath.Join(dir, "plain"), filepath.Join(dir, "atomic")
+	buffer := NewSeekBuffer([]byte("data"))
+	if err := buffer.SaveToFile(plain); err != nil {
+		t.Fatal(err)
+	}
+	if err := buffer.SaveToFileAtomic(atomic); err != nil {
+		t.Fatal(err)
+	}
+	plainInfo, _ := os.Stat(plain)
+	atomicInfo, _ := os.Stat(atomic)
+	if plainInfo.Mode().Perm()&^0644 != 0 {
+		t.Errorf("default mode should not exceed 0644, but got %v", plainInfo.Mode().Perm())
+	}
+	if plainInfo.Mode().Perm() != atomicInfo.Mode().Perm() {
+		t.Errorf("both saves should create %v, but atomic created %v", plainInfo.Mode().Perm(), atomicInfo.Mode().Perm())
+	}
+}
+
+func TestFileOptions_ExistingTarget(t *testing.T) {
+	dir := t.TempDir()
+	buffer := NewSeekBuffer([]byte("data"))
+	for name, save := range map[string]func(string, ...FileOption) error{
+		"plain":  buffer.SaveToFile,
+		"atomic": buffer.SaveToFileAtomic,
+	} {
+		filename := filepath.Join(dir, name)
+		os.WriteFile(filename, nil, 0600)
+		os.Chmod(filename, 0600)
+		if err := save(filename, WithFileMode(0644)); err != nil {
+			t.Fatal(err)
+		}
+		info, _ := os.Stat(filename)
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("%s: existing mode 0600 should be kept, but got %v", name, info.Mode().Perm())
+		}
+	}
+}
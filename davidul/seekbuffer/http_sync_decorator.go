@@ -0,0 +1,179 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// what HTTPSyncDecorator sends on every push
+type HTTPSyncMode int
+
+const (
+	// PUT the whole content
+	HTTPSnapshot HTTPSyncMode = iota
+	// PATCH only the bytes appended since the last push, the position is
+	// given in a Content-Range header, falls back to a PUT when the pushed
+	// content was shortened or rewritten, e.g. by an expiring buffer below
+	HTTPDelta
+)
+
+// returned when the endpoint answers with a non-2xx status
+var ErrUnexpectedStatus = errors.New("seekbuffer: unexpected HTTP status")
+
+// configures HTTPSyncDecorator
+type HTTPSyncConfig struct {
+	// endpoint receiving the content
+	URL  string
+	Mode HTTPSyncMode
+	// defaults to http.DefaultClient
+	Client *http.Client
+	// pending bytes which trigger a push, 0 pushes on every write
+	BatchSize int
+	// called on every request before it is sent, e.g. to add credentials
+	Authorize func(*http.Request) error
+}
+
+// decorator pushing the content of the wrapped buffer to an HTTP endpoint
+type HTTPSyncDecorator struct {
+	buffer SeekableBuffer
+	config HTTPSyncConfig
+	// length and hash of the content at the last successful push
+	pushed    int
+	pushedSum [sha256.Size]byte
+	dirty     bool
+}
+
+var _ SeekableBuffer = (*HTTPSyncDecorator)(nil)
+
+// wraps buffer, the current content counts as not yet pushed
+func NewHTTPSyncDecorator(buffer SeekableBuffer, config HTTPSyncConfig) *HTTPSyncDecorator {
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	return &HTTPSyncDecorator{
+		buffer:    buffer,
+		config:    config,
+		pushedSum: sha256.Sum256(nil),
+		dirty:     len(buffer.Bytes()) > 0,
+	}
+}
+
+// number of bytes not pushed yet, the whole content once it shrank below
+// what was pushed
+func (d *HTTPSyncDecorator) Pending() int {
+	if !d.dirty {
+		return 0
+	}
+	size := len(d.buffer.Bytes())
+	if size < d.pushed {
+		return size
+	}
+	return size - d.pushed
+}
+
+// pushes pending changes to the endpoint
+func (d *HTTPSyncDecorator) Flush(ctx context.Context) error {
+	if !d.dirty {
+		return nil
+	}
+	content := d.buffer.Bytes()
+	method, body := http.MethodPut, content
+	delta := d.config.Mode == HTTPDelta && len(content) >= d.pushed &&
+		sha256.Sum256(content[:d.pushed]) == d.pushedSum
+	if delta {
+		method, body = http.MethodPatch, content[d.pushed:]
+	}
+	req, err := http.NewRequestWithContext(ctx, method, d.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if delta && len(body) > 0 {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", d.pushed, len(content)-1, len(content)))
+	}
+	if d.config.Authorize != nil {
+		if err := d.config.Authorize(req); err != nil {
+			return err
+		}
+	}
+	resp, err := d.config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %s", ErrUnexpectedStatus, resp.Status)
+	}
+	d.pushed = len(content)
+	d.pushedSum = sha256.Sum256(content)
+	d.dirty = false
+	return nil
+}
+
+// records a change and pushes once the batch is full
+func (d *HTTPSyncDecorator) changed(n int) error {
+	if n == 0 {
+		return nil
+	}
+	d.dirty = true
+	if d.Pending() < d.config.BatchSize {
+		return nil
+	}
+	return d.Flush(context.Background())
+}
+
+func (d *HTTPSyncDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+// appends content to the buffer and pushes it when the batch is full
+func (d *HTTPSyncDecorator) Append(src []byte) error {
+	if err := d.buffer.Append(src); err != nil {
+		return err
+	}
+	return d.changed(len(src))
+}
+
+// writes content to the buffer and pushes it when the batch is full
+func (d *HTTPSyncDecorator) Write(src []byte) (int, error) {
+	n, err := d.buffer.Write(src)
+	if err != nil {
+		return n, err
+	}
+	return n, d.changed(n)
+}
+
+func (d *HTTPSyncDecorator) Read(dst []byte) (int, error) {
+	return d.buffer.Read(dst)
+}
+
+func (d *HTTPSyncDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *HTTPSyncDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+// pushes pending changes and closes the buffer, the buffer stays open
+// when the push fails so Close can be retried
+func (d *HTTPSyncDecorator) Close() error {
+	if err := d.Flush(context.Background()); err != nil {
+		return err
+	}
+	return d.buffer.Close()
+}
+
+func (d *HTTPSyncDecorator) ReadBytes(c byte) ([]byte, error) {
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *HTTPSyncDecorator) Len() int {
+	return d.buffer.Len()
+}
@@ -0,0 +1,157 @@
+package seekbuffer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// request received by the test server
+type receivedRequest struct {
+	method       string
+	body         string
+	contentRange string
+	auth         string
+}
+
+func newRecordingServer(status int) (*httptest.Server, func() []receivedRequest) {
+	var mu sync.Mutex
+	var requests []receivedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, receivedRequest{
+			method:       r.Method,
+			body:         string(body),
+			contentRange: r.Header.Get("Content-Range"),
+			auth:         r.Header.Get("Authorization"),
+		})
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	return server, func() []receivedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]receivedRequest(nil), requests...)
+	}
+}
+
+func TestHTTPSyncDecorator_Snapshot(t *testing.T) {
+	server, requests := newRecordingServer(http.StatusNoContent)
+	defer server.Close()
+	decorator := NewHTTPSyncDecorator(NewEmptySeekBuffer(), HTTPSyncConfig{
+		URL: server.URL,
+		Authorize: func(r *http.Request) error {
+			r.Header.Set("Authorization", "Bearer token")
+			return nil
+		},
+	})
+	decorator.Write([]byte("ab"))
+	decorator.Append([]byte("c"))
+	got := requests()
+	if len(got) != 2 {
+		t.Errorf("requests should be 2, but got %d", len(got))
+	}
+	if got[1].method != http.MethodPut || got[1].body != "abc" {
+		t.Errorf("request should PUT abc, but got %s %q", got[1].method, got[1].body)
+	}
+	if got[1].auth != "Bearer token" {
+		t.Errorf("authorization should be set, but got %q", got[1].auth)
+	}
+}
+
+func TestHTTPSyncDecorator_DeltaBatch(t *testing.T) {
+	server, requests := newRecordingServer(http.StatusOK)
+	defer server.Close()
+	decorator := NewHTTPSyncDecorator(NewSeekBuffer([]byte("ab")), HTTPSyncConfig{
+		URL:       server.URL,
+		Mode:      HTTPDelta,
+		BatchSize: 4,
+	})
+	decorator.Write([]byte("c"))
+	if len(requests()) != 0 {
+		t.Errorf("requests should be 0, but got %d", len(requests()))
+	}
+	decorator.Write([]byte("d"))
+	decorator.Write([]byte("ef"))
+	if err := decorator.Close(); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	got := requests()
+	if len(got) != 2 {
+		t.Errorf("requests should be 2, but got %d", len(got))
+	}
+	if got[0].method != http.MethodPatch || got[0].body != "abcd" || got[0].contentRange != "bytes 0-3/4" {
+		t.Errorf("first request should patch abcd, but got %+v", got[0])
+	}
+	if got[1].body != "ef" || got[1].contentRange != "bytes 4-5/6" {
+		t.Errorf("second request should patch ef, but got %+v", got[1])
+	}
+}
+
+func TestHTTPSyncDecorator_Status(t *testing.T) {
+	server, _ := newRecordingServer(http.StatusForbidden)
+	defer server.Close()
+	decorator := NewHTTPSyncDecorator(NewEmptySeekBuffer(), HTTPSyncConfig{URL: server.URL, BatchSize: 10})
+	decorator.Write([]byte("abc"))
+	if err := decorator.Flush(context.Background()); !errors.Is(err, ErrUnexpectedStatus) {
+		t.Errorf("error should be ErrUnexpectedStatus, but got %v", err)
+	}
+	if decorator.Pending() != 3 {
+		t.Errorf("pending should be 3, but got %d", decorator.Pending())
+	}
+}
+
+func TestHTTPSyncDecorator_DeltaShrunk(t *testing.T) {
+	server, requests := newRecordingServer(http.StatusOK)
+	defer server.Close()
+	ttl, _ := NewTTLDecorator(NewEmptySeekBuffer(), TTLConfig{})
+	decorator := NewHTTPSyncDecorator(ttl, HTTPSyncConfig{URL: server.URL, Mode: HTTPDelta})
+	decorator.Write([]byte("abcd"))
+	ttl.Expire(0)
+	if decorator.Pending() != 0 {
+		t.Errorf("pending should not be negative, but got %d", decorator.Pending())
+	}
+	decorator.Write([]byte("x"))
+	if err := decorator.Close(); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	got := requests()
+	if last := got[len(got)-1]; last.method != http.MethodPut || last.body != "x" {
+		t.Errorf("shrunk content should be PUT in full, but got %+v", last)
+	}
+}
+
+func TestHTTPSyncDecorator_DeltaRewritten(t *testing.T) {
+	server, requests := newRecordingServer(http.StatusOK)
+	defer server.Close()
+	quota, _ := NewQuotaDecorator(NewEmptySeekBuffer(), QuotaConfig{MaxSize: 4, Policy: QuotaDropOldest})
+	decorator := NewHTTPSyncDecorator(quota, HTTPSyncConfig{URL: server.URL, Mode: HTTPDelta})
+	decorator.Write([]byte("abcd"))
+	decorator.Write([]byte("ef"))
+	got := requests()
+	if last := got[len(got)-1]; last.method != http.MethodPut || last.body != "cdef" {
+		t.Errorf("rewritten content should be PUT in full, but got %+v", last)
+	}
+	decorator.Write([]byte("g"))
+	if last := requests()[len(requests())-1]; last.method != http.MethodPut || last.body != "defg" {
+		t.Errorf("dropped prefix should be PUT in full, but got %+v", last)
+	}
+}
+
+func TestHTTPSyncDecorator_CloseFailure(t *testing.T) {
+	server, _ := newRecordingServer(http.StatusInternalServerError)
+	defer server.Close()
+	decorator := NewHTTPSyncDecorator(NewEmptySeekBuffer(), HTTPSyncConfig{URL: server.URL, BatchSize: 10})
+	decorator.Write([]byte("abc"))
+	if err := decorator.Close(); !errors.Is(err, ErrUnexpectedStatus) {
+		t.Errorf("error should be ErrUnexpectedStatus, but got %v", err)
+	}
+	if string(decorator.Bytes()) != "abc" {
+		t.Errorf("buffer should stay open after a failed push, but got %q", decorator.Bytes())
+	}
+}
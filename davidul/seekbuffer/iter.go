@@ -0,0 +1,44 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"iter"
+)
+
+// iterates over the unread lines, each line includes the trailing newline,
+// the offset advances past every line yielded
+func (s *SeekBuffer) Lines() iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		for s.offset < len(s.buffer) {
+			end := len(s.buffer)
+			if i := bytes.IndexByte(s.buffer[s.offset:], '\n'); i != -1 {
+				end = s.offset + i + 1
+			}
+			line := s.buffer[s.offset:end]
+			s.offset = end
+			s.lastRead = opRead
+			if !yield(line) {
+				return
+			}
+		}
+	}
+}
+
+// iterates over the unread content in chunks of n bytes, the last chunk
+// may be shorter, the offset advances past every chunk yielded
+func (s *SeekBuffer) Chunks(n int) iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		if n <= 0 {
+			return
+		}
+		for s.offset < len(s.buffer) {
+			end := min(s.offset+n, len(s.buffer))
+			chunk := s.buffer[s.offset:end]
+			s.offset = end
+			s.lastRead = opRead
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
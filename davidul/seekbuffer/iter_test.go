@@ -0,0 +1,49 @@
+package seekbuffer
+
+import (
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("a\nbc\nd"))
+	var lines []string
+	for line := range buffer.Lines() {
+		lines = append(lines, string(line))
+	}
+	if len(lines) != 3 {
+		t.Errorf("lines should be 3, but got %d", len(lines))
+	}
+	if lines[1] != "bc\n" {
+		t.Errorf("line should be bc, but got %q", lines[1])
+	}
+	if buffer.offset != 6 {
+		t.Errorf("offset should be 6, but got %d", buffer.offset)
+	}
+}
+
+func TestLines_Break(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("a\nbc\nd"))
+	for range buffer.Lines() {
+		break
+	}
+	if buffer.offset != 2 {
+		t.Errorf("offset should be 2, but got %d", buffer.offset)
+	}
+}
+
+func TestChunks(t *testing.T) {
+	buffer := NewSeekBuffer([]byte{1, 2, 3, 4, 5})
+	var sizes []int
+	for chunk := range buffer.Chunks(2) {
+		sizes = append(sizes, len(chunk))
+	}
+	if len(sizes) != 3 {
+		t.Errorf("chunks should be 3, but got %d", len(sizes))
+	}
+	if sizes[2] != 1 {
+		t.Errorf("last chunk should be 1, but got %d", sizes[2])
+	}
+	if buffer.offset != 5 {
+		t.Errorf("offset should be 5, but got %d", buffer.offset)
+	}
+}
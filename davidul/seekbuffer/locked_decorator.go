@@ -0,0 +1,89 @@
+package seekbuffer
+
+import (
+	"sync"
+)
+
+// decorator making the wrapped buffer safe for concurrent use, every call
+// holds the lock exclusively since reads advance the offset and decorators
+// such as TransformDecorator fill caches even in Bytes and Len
+type LockedDecorator struct {
+	mu     sync.Mutex
+	buffer SeekableBuffer
+}
+
+var _ SeekableBuffer = (*LockedDecorator)(nil)
+
+// wraps buffer, which must not be used directly afterwards
+func NewLockedDecorator(buffer SeekableBuffer) *LockedDecorator {
+	return &LockedDecorator{
+		buffer: buffer,
+	}
+}
+
+// runs fn with exclusive access to the wrapped buffer, e.g. to combine
+// several calls atomically
+func (d *LockedDecorator) Do(fn func(SeekableBuffer) error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return fn(d.buffer)
+}
+
+// returns a copy of the content since the storage may change once the
+// lock is released
+func (d *LockedDecorator) Bytes() []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	content := d.buffer.Bytes()
+	b := make([]byte, len(content))
+	copy(b, content)
+	return b
+}
+
+func (d *LockedDecorator) Append(src []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Append(src)
+}
+
+func (d *LockedDecorator) Write(src []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Write(src)
+}
+
+func (d *LockedDecorator) Read(dst []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Read(dst)
+}
+
+func (d *LockedDecorator) Rewind() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buffer.Rewind()
+}
+
+func (d *LockedDecorator) Seek(offset int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buffer.Seek(offset)
+}
+
+func (d *LockedDecorator) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Close()
+}
+
+func (d *LockedDecorator) ReadBytes(c byte) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *LockedDecorator) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Len()
+}
@@ -0,0 +1,81 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestLockedDecorator_Concurrent(t *testing.T) {
+	const writers, lines = 8, 100
+	checksum, err := NewChecksumDecorator(NewEmptySeekBuffer(), ChecksumCRC32C)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compression, err := NewCompressionDecorator(NewEmptySeekBuffer(), GzipCodec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stacks := map[string]SeekableBuffer{
+		"seekbuffer":  NewEmptySeekBuffer(),
+		"chunked":     NewChunkedSeekBuffer(16),
+		"tee":         NewTeeDecorator(NewEmptySeekBuffer(), io.Discard),
+		"observer":    NewObserverDecorator(NewEmptySeekBuffer()),
+		"codec":       NewCodecDecorator(NewEmptySeekBuffer(), Base64Encoding),
+		"checksum":    checksum,
+		"compression": compression,
+	}
+	for name, buffer := range stacks {
+		decorator := NewLockedDecorator(buffer)
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		read := 0
+		for i := 0; i < writers; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < lines; j++ {
+					decorator.Write([]byte("line\n"))
+					if j%20 == 0 {
+						decorator.Bytes()
+					}
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for j := 0; j < lines; j++ {
+					line, err := decorator.ReadBytes('\n')
+					if err == nil {
+						mu.Lock()
+						read += len(line)
+						mu.Unlock()
+					} else if err != io.EOF {
+						t.Errorf("%s: read should succeed, but got %v", name, err)
+					}
+					decorator.Len()
+				}
+			}()
+		}
+		wg.Wait()
+
+		want := bytes.Repeat([]byte("line\n"), writers*lines)
+		if !bytes.Equal(decorator.Bytes(), want) {
+			t.Errorf("%s: content should hold every line, but got %d bytes", name, len(decorator.Bytes()))
+		}
+		if read+decorator.Len() != len(want) {
+			t.Errorf("%s: read and unread bytes should add up to %d, but got %d", name, len(want), read+decorator.Len())
+		}
+	}
+}
+
+func TestLockedDecorator_Do(t *testing.T) {
+	decorator := NewLockedDecorator(NewEmptySeekBuffer())
+	decorator.Do(func(b SeekableBuffer) error {
+		b.Write([]byte("a"))
+		return b.Append([]byte("b"))
+	})
+	if string(decorator.Bytes()) != "ab" {
+		t.Errorf("content should be ab, but got %q", decorator.Bytes())
+	}
+}
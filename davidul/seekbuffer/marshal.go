@@ -0,0 +1,99 @@
+package seekbuffer
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"unicode/utf8"
+)
+
+// version of the binary encoding
+const binaryVersion = 1
+
+// returned when decoding malformed buffer state
+var ErrInvalidEncoding = errors.New("seekbuffer: invalid encoding")
+
+// encodes the content and offset of the buffer as a version byte,
+// the offset as uvarint and the raw content, an offset past the end is
+// encoded as the end
+func (s *SeekBuffer) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 1+binary.MaxVarintLen64+len(s.buffer))
+	data = append(data, binaryVersion)
+	data = binary.AppendUvarint(data, uint64(min(s.offset, len(s.buffer))))
+	data = append(data, s.buffer...)
+	return data, nil
+}
+
+// restores content and offset encoded by MarshalBinary
+func (s *SeekBuffer) UnmarshalBinary(data []byte) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if len(data) == 0 || data[0] != binaryVersion {
+		return ErrInvalidEncoding
+	}
+	offset, n := binary.Uvarint(data[1:])
+	if n <= 0 {
+		return ErrInvalidEncoding
+	}
+	content := data[1+n:]
+	if offset > uint64(len(content)) {
+		return ErrInvalidEncoding
+	}
+	s.buffer = make([]byte, len(content))
+	copy(s.buffer, content)
+	s.offset = int(offset)
+	s.version++
+	s.lastRead = opInvalid
+	return nil
+}
+
+// JSON form of the buffer state, content is base64 encoded unless it
+// is emitted as text
+type jsonState struct {
+	Content []byte  `json:"content,omitempty"`
+	Text    *string `json:"text,omitempty"`
+	Offset  int     `json:"offset"`
+}
+
+// makes MarshalJSON emit the content as a string when it is valid UTF-8
+func (s *SeekBuffer) SetJSONText(enabled bool) {
+	s.jsonText = enabled
+}
+
+// encodes the content and offset of the buffer as JSON, an offset past
+// the end is encoded as the end
+func (s *SeekBuffer) MarshalJSON() ([]byte, error) {
+	state := jsonState{Offset: min(s.offset, len(s.buffer))}
+	if s.jsonText && utf8.Valid(s.buffer) {
+		text := string(s.buffer)
+		state.Text = &text
+	} else {
+		state.Content = s.buffer
+	}
+	return json.Marshal(state)
+}
+
+// restores content and offset encoded by MarshalJSON
+func (s *SeekBuffer) UnmarshalJSON(data []byte) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	var state jsonState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	content := state.Content
+	if state.Text != nil {
+		content = []byte(*state.Text)
+	}
+	if state.Offset < 0 || state.Offset > len(content) {
+		return ErrInvalidEncoding
+	}
+	s.buffer = make([]byte, len(content))
+	copy(s.buffer, content)
+	s.offset = state.Offset
+	s.version++
+	s.lastRead = opInvalid
+	return nil
+}
@@ -0,0 +1,133 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("hello"))
+	buffer.Seek(2)
+	data, err := buffer.MarshalBinary()
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	restored := NewEmptySeekBuffer()
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if restored.offset != 2 {
+		t.Errorf("offset should be 2, but got %d", restored.offset)
+	}
+	if string(restored.buffer) != "hello" {
+		t.Errorf("content should be hello, but got %q", restored.buffer)
+	}
+}
+
+func TestMarshalBinary_Gob(t *testing.T) {
+	buffer := NewSeekBuffer([]byte{1, 2, 3})
+	buffer.Seek(3)
+	var network bytes.Buffer
+	if err := gob.NewEncoder(&network).Encode(buffer); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	restored := NewEmptySeekBuffer()
+	if err := gob.NewDecoder(&network).Decode(restored); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if restored.offset != 3 || len(restored.buffer) != 3 {
+		t.Errorf("state should match, but got offset %d, len %d", restored.offset, len(restored.buffer))
+	}
+}
+
+func TestMarshalBinary_PastEnd(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("abc"))
+	buffer.Seek(10)
+	data, _ := buffer.MarshalBinary()
+	restored := NewEmptySeekBuffer()
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("error should be nil, but got %v", err)
+	}
+	if restored.offset != 3 || restored.String() != "" {
+		t.Errorf("offset should be clamped to 3, but got %d", restored.offset)
+	}
+}
+
+func TestUnmarshalBinary_Invalid(t *testing.T) {
+	buffer := NewEmptySeekBuffer()
+	if err := buffer.UnmarshalBinary(nil); err != ErrInvalidEncoding {
+		t.Errorf("error should be ErrInvalidEncoding, but got %v", err)
+	}
+	if err := buffer.UnmarshalBinary([]byte{2, 0}); err != ErrInvalidEncoding {
+		t.Errorf("error should be ErrInvalidEncoding, but got %v", err)
+	}
+	if err := buffer.UnmarshalBinary([]byte{binaryVersion, 4, 1}); err != ErrInvalidEncoding {
+		t.Errorf("error should be ErrInvalidEncoding, but got %v", err)
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	buffer := NewSeekBuffer([]byte{0xff, 1})
+	buffer.Seek(1)
+	data, err := json.Marshal(buffer)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if string(data) != `{"content":"/wE=","offset":1}` {
+		t.Errorf("json should be base64, but got %s", data)
+	}
+	restored := NewEmptySeekBuffer()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if !restored.Equal(buffer) || restored.offset != 1 {
+		t.Errorf("state should match, but got %v at %d", restored.buffer, restored.offset)
+	}
+}
+
+func TestMarshalJSON_Text(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("héllo"))
+	buffer.SetJSONText(true)
+	data, _ := json.Marshal(buffer)
+	if string(data) != `{"text":"héllo","offset":0}` {
+		t.Errorf("json should be text, but got %s", data)
+	}
+	restored := NewEmptySeekBuffer()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if restored.String() != "héllo" {
+		t.Errorf("content should be héllo, but got %q", restored.String())
+	}
+	buffer.Append([]byte{0xff})
+	data, _ = json.Marshal(buffer)
+	if !bytes.Contains(data, []byte(`"content"`)) {
+		t.Errorf("invalid UTF-8 should fall back to base64, but got %s", data)
+	}
+}
+
+func TestMarshalJSON_PastEnd(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("abc"))
+	buffer.SetJSONText(true)
+	buffer.Seek(10)
+	data, err := json.Marshal(buffer)
+	if err != nil {
+		t.Fatalf("error should be nil, but got %v", err)
+	}
+	restored := NewEmptySeekBuffer()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("error should be nil, but got %v", err)
+	}
+	if restored.offset != 3 || string(restored.buffer) != "abc" {
+		t.Errorf("state should be abc at 3, but got %q at %d", restored.buffer, restored.offset)
+	}
+}
+
+func TestUnmarshalJSON_Invalid(t *testing.T) {
+	buffer := NewEmptySeekBuffer()
+	if err := json.Unmarshal([]byte(`{"text":"ab","offset":3}`), buffer); err != ErrInvalidEncoding {
+		t.Errorf("error should be ErrInvalidEncoding, but got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package seekbuffer
+
+import (
+	"errors"
+	"io"
+)
+
+// how MultiSyncDecorator handles a failing target
+type FailurePolicy int
+
+const (
+	// a write fails when any target fails, the other targets still
+	// receive it so they stay in sync
+	FailFast FailurePolicy = iota
+	// a write succeeds while at least one target is healthy
+	BestEffort
+)
+
+// returned when a write reaches no healthy target
+var ErrNoHealthyTarget = errors.New("seekbuffer: no healthy sync target")
+
+// decorator mirroring every write of the wrapped buffer to several targets,
+// a target that fails once is out of sync and receives no further writes
+type MultiSyncDecorator struct {
+	buffer  SeekableBuffer
+	policy  FailurePolicy
+	targets []io.Writer
+	errs    []error
+}
+
+var _ SeekableBuffer = (*MultiSyncDecorator)(nil)
+
+// wraps buffer, writes are mirrored to the targets added with AddTarget
+func NewMultiSyncDecorator(buffer SeekableBuffer, policy FailurePolicy) *MultiSyncDecorator {
+	return &MultiSyncDecorator{
+		buffer: buffer,
+		policy: policy,
+	}
+}
+
+// writes the current content of the buffer to w and mirrors every
+// following write to it, returns the index of the target
+func (d *MultiSyncDecorator) AddTarget(w io.Writer) (int, error) {
+	if _, err := writeChunks(w, d.buffer.Bytes()); err != nil {
+		return -1, err
+	}
+	d.targets = append(d.targets, w)
+	d.errs = append(d.errs, nil)
+	return len(d.targets) - 1, nil
+}
+
+// returns the error of every target in the order they were added,
+// nil for targets in sync
+func (d *MultiSyncDecorator) Errors() []error {
+	errs := make([]error, len(d.errs))
+	copy(errs, d.errs)
+	return errs
+}
+
+// mirrors src to every healthy target according to the policy
+func (d *MultiSyncDecorator) sync(src []byte) error {
+	var errs []error
+	healthy := 0
+	// targets are all written before the policy is applied so a failing
+	// target cannot leave a hole in the ones after it
+	for i, w := range d.targets {
+		if d.errs[i] != nil {
+			continue
+		}
+		if _, err := writeChunks(w, src); err != nil {
+			d.errs[i] = err
+			errs = append(errs, err)
+			continue
+		}
+		healthy++
+	}
+	if d.policy == FailFast && len(errs) > 0 {
+		return errs[0]
+	}
+	if healthy == 0 && len(d.targets) > 0 {
+		return errors.Join(append([]error{ErrNoHealthyTarget}, errs...)...)
+	}
+	return nil
+}
+
+func (d *MultiSyncDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+// appends content to the buffer and mirrors it to the targets
+func (d *MultiSyncDecorator) Append(src []byte) error {
+	if err := d.buffer.Append(src); err != nil {
+		return err
+	}
+	return d.sync(src)
+}
+
+// writes content to the buffer and mirrors it to the targets
+func (d *MultiSyncDecorator) Write(src []byte) (int, error) {
+	n, err := d.buffer.Write(src)
+	if err != nil {
+		return n, err
+	}
+	return n, d.sync(src[:n])
+}
+
+func (d *MultiSyncDecorator) Read(dst []byte) (int, error) {
+	return d.buffer.Read(dst)
+}
+
+func (d *MultiSyncDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *MultiSyncDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+// closes the buffer and drops all targets, the targets are left open
+func (d *MultiSyncDecorator) Close() error {
+	d.targets = nil
+	d.errs = nil
+	return d.buffer.Close()
+}
+
+func (d *MultiSyncDecorator) ReadBytes(c byte) ([]byte, error) {
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *MultiSyncDecorator) Len() int {
+	return d.buffer.Len()
+}
@@ -0,0 +1,80 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+var errTarget = errors.New("target failed")
+
+// fails every write once armed
+type failingWriter struct {
+	bytes.Buffer
+	fail bool
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.fail {
+		return 0, errTarget
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestMultiSyncDecorator(t *testing.T) {
+	decorator := NewMultiSyncDecorator(NewSeekBuffer([]byte("a")), FailFast)
+	var first, second bytes.Buffer
+	decorator.AddTarget(&first)
+	decorator.Write([]byte("b"))
+	if i, _ := decorator.AddTarget(&second); i != 1 {
+		t.Errorf("index should be 1, but got %d", i)
+	}
+	decorator.Append([]byte("c"))
+	if first.String() != "abc" || second.String() != "abc" {
+		t.Errorf("targets should be abc, but got %q and %q", first.String(), second.String())
+	}
+}
+
+func TestMultiSyncDecorator_FailFast(t *testing.T) {
+	decorator := NewMultiSyncDecorator(NewEmptySeekBuffer(), FailFast)
+	first := &failingWriter{}
+	var second bytes.Buffer
+	decorator.AddTarget(first)
+	decorator.AddTarget(&second)
+	first.fail = true
+	if _, err := decorator.Write([]byte("a")); err != errTarget {
+		t.Errorf("error should be errTarget, but got %v", err)
+	}
+	if second.String() != "a" {
+		t.Errorf("second target should still receive a, but got %q", second.String())
+	}
+	if errs := decorator.Errors(); errs[0] != errTarget || errs[1] != nil {
+		t.Errorf("errors should be [errTarget nil], but got %v", errs)
+	}
+	if _, err := decorator.Write([]byte("b")); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if second.String() != string(decorator.Bytes()) {
+		t.Errorf("second target should match the buffer %q, but got %q", decorator.Bytes(), second.String())
+	}
+}
+
+func TestMultiSyncDecorator_BestEffort(t *testing.T) {
+	decorator := NewMultiSyncDecorator(NewEmptySeekBuffer(), BestEffort)
+	first := &failingWriter{}
+	second := &failingWriter{}
+	decorator.AddTarget(first)
+	decorator.AddTarget(second)
+	first.fail = true
+	if _, err := decorator.Write([]byte("a")); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if second.String() != "a" {
+		t.Errorf("second target should be a, but got %q", second.String())
+	}
+	second.fail = true
+	_, err := decorator.Write([]byte("b"))
+	if !errors.Is(err, ErrNoHealthyTarget) || !errors.Is(err, errTarget) {
+		t.Errorf("error should be ErrNoHealthyTarget, but got %v", err)
+	}
+}
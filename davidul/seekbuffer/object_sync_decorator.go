@@ -0,0 +1,235 @@
+package seekbuffer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// default part size of multipart uploads
+const defaultPartSize = 8 << 20
+
+// client of an S3-compatible object store
+type Uploader interface {
+	// stores data under key in a single request
+	PutObject(ctx context.Context, key string, data []byte) error
+	// starts a multipart upload and returns its id
+	CreateMultipartUpload(ctx context.Context, key string) (string, error)
+	// uploads one part, part numbers start at 1, returns the part ETag
+	UploadPart(ctx context.Context, key, uploadID string, partNumber int, data []byte) (string, error)
+	// assembles the parts identified by their ETags
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, etags []string) error
+	// discards an unfinished multipart upload
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+}
+
+// configures ObjectSyncDecorator
+type ObjectSyncConfig struct {
+	// object key the content is stored under
+	Key string
+	// content larger than this is uploaded in parts, defaults to 8 MiB
+	PartSize int
+	// uploads changed content periodically when positive, otherwise
+	// content is only uploaded on Commit
+	Interval time.Duration
+	// receives errors of periodic uploads
+	OnError func(error)
+}
+
+// decorator persisting the content of the wrapped buffer to an object store,
+// safe for concurrent use
+type ObjectSyncDecorator struct {
+	mu       sync.Mutex
+	buffer   SeekableBuffer
+	uploader Uploader
+	config   ObjectSyncConfig
+	// incremented on every write
+	generation uint64
+	// generation of the last successful upload
+	uploaded uint64
+	// serializes uploads
+	uploadMu sync.Mutex
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+var _ SeekableBuffer = (*ObjectSyncDecorator)(nil)
+
+// wraps buffer, the current content counts as not yet uploaded
+func NewObjectSyncDecorator(buffer SeekableBuffer, uploader Uploader, config ObjectSyncConfig) *ObjectSyncDecorator {
+	if config.PartSize <= 0 {
+		config.PartSize = defaultPartSize
+	}
+	d := &ObjectSyncDecorator{
+		buffer:     buffer,
+		uploader:   uploader,
+		config:     config,
+		generation: 1,
+	}
+	if config.Interval > 0 {
+		d.stop = make(chan struct{})
+		d.done = make(chan struct{})
+		go d.loop()
+	}
+	return d
+}
+
+// uploads changed content every interval until stopped
+func (d *ObjectSyncDecorator) loop() {
+	defer close(d.done)
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			if err := d.Commit(context.Background()); err != nil && d.config.OnError != nil {
+				d.config.OnError(err)
+			}
+		}
+	}
+}
+
+// reports whether the buffer changed since the last upload
+func (d *ObjectSyncDecorator) Dirty() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.generation != d.uploaded
+}
+
+// uploads the content if it changed since the last upload
+func (d *ObjectSyncDecorator) Commit(ctx context.Context) error {
+	d.uploadMu.Lock()
+	defer d.uploadMu.Unlock()
+
+	d.mu.Lock()
+	if d.generation == d.uploaded {
+		d.mu.Unlock()
+		return nil
+	}
+	generation := d.generation
+	data := make([]byte, len(d.buffer.Bytes()))
+	copy(data, d.buffer.Bytes())
+	d.mu.Unlock()
+
+	if err := d.upload(ctx, data); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	d.uploaded = generation
+	d.mu.Unlock()
+	return nil
+}
+
+// stores data in one request or in parts when it is large
+func (d *ObjectSyncDecorator) upload(ctx context.Context, data []byte) error {
+	key := d.config.Key
+	if len(data) <= d.config.PartSize {
+		return d.uploader.PutObject(ctx, key, data)
+	}
+	uploadID, err := d.uploader.CreateMultipartUpload(ctx, key)
+	if err != nil {
+		return err
+	}
+	var etags []string
+	for part := 1; len(data) > 0; part++ {
+		n := min(len(data), d.config.PartSize)
+		etag, err := d.uploader.UploadPart(ctx, key, uploadID, part, data[:n])
+		if err != nil {
+			return errors.Join(err, d.uploader.AbortMultipartUpload(ctx, key, uploadID))
+		}
+		etags = append(etags, etag)
+		data = data[n:]
+	}
+	if err := d.uploader.CompleteMultipartUpload(ctx, key, uploadID, etags); err != nil {
+		return errors.Join(err, d.uploader.AbortMultipartUpload(ctx, key, uploadID))
+	}
+	return nil
+}
+
+func (d *ObjectSyncDecorator) Bytes() []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Bytes()
+}
+
+// appends content to the buffer and marks it for upload
+func (d *ObjectSyncDecorator) Append(src []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.buffer.Append(src); err != nil {
+		return err
+	}
+	d.generation++
+	return nil
+}
+
+// writes content to the buffer and marks it for upload
+func (d *ObjectSyncDecorator) Write(src []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n, err := d.buffer.Write(src)
+	if n > 0 {
+		d.generation++
+	}
+	return n, err
+}
+
+func (d *ObjectSyncDecorator) Read(dst []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Read(dst)
+}
+
+func (d *ObjectSyncDecorator) Rewind() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buffer.Rewind()
+}
+
+func (d *ObjectSyncDecorator) Seek(offset int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buffer.Seek(offset)
+}
+
+// stops periodic uploads, uploads pending changes and closes the buffer,
+// the buffer stays open when the upload fails so Close can be retried
+func (d *ObjectSyncDecorator) Close() error {
+	if d.stop != nil {
+		d.mu.Lock()
+		select {
+		case <-d.stop:
+		default:
+			close(d.stop)
+		}
+		d.mu.Unlock()
+		<-d.done
+	}
+	for {
+		if err := d.Commit(context.Background()); err != nil {
+			return err
+		}
+		d.mu.Lock()
+		// a write may have landed after the upload
+		if d.generation == d.uploaded {
+			defer d.mu.Unlock()
+			return d.buffer.Close()
+		}
+		d.mu.Unlock()
+	}
+}
+
+func (d *ObjectSyncDecorator) ReadBytes(c byte) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *ObjectSyncDecorator) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Len()
+}
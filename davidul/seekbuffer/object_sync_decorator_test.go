@@ -0,0 +1,202 @@
+package seekbuffer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// in-memory object store
+type memoryUploader struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	parts   map[string][][]byte
+	puts    int
+	aborted int
+	failAt  int
+	// called outside the lock on every PutObject
+	onPut func()
+}
+
+func newMemoryUploader() *memoryUploader {
+	return &memoryUploader{
+		objects: map[string][]byte{},
+		parts:   map[string][][]byte{},
+	}
+}
+
+func (u *memoryUploader) PutObject(ctx context.Context, key string, data []byte) error {
+	if u.onPut != nil {
+		u.onPut()
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.puts++
+	u.objects[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (u *memoryUploader) CreateMultipartUpload(ctx context.Context, key string) (string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	id := fmt.Sprintf("upload-%d", len(u.parts))
+	u.parts[id] = nil
+	return id, nil
+}
+
+func (u *memoryUploader) UploadPart(ctx context.Context, key, uploadID string, partNumber int, data []byte) (string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if partNumber == u.failAt {
+		return "", errTarget
+	}
+	u.parts[uploadID] = append(u.parts[uploadID], append([]byte(nil), data...))
+	return fmt.Sprintf("etag-%d", partNumber), nil
+}
+
+func (u *memoryUploader) CompleteMultipartUpload(ctx context.Context, key, uploadID string, etags []string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	var data []byte
+	for _, part := range u.parts[uploadID] {
+		data = append(data, part...)
+	}
+	u.objects[key] = data
+	return nil
+}
+
+func (u *memoryUploader) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.aborted++
+	delete(u.parts, uploadID)
+	return nil
+}
+
+func (u *memoryUploader) object(key string) string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return string(u.objects[key])
+}
+
+func TestObjectSyncDecorator_Commit(t *testing.T) {
+	uploader := newMemoryUploader()
+	decorator := NewObjectSyncDecorator(NewSeekBuffer([]byte("ab")), uploader, ObjectSyncConfig{Key: "k"})
+	decorator.Write([]byte("c"))
+	if !decorator.Dirty() {
+		t.Errorf("decorator should be dirty")
+	}
+	if err := decorator.Commit(context.Background()); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if uploader.object("k") != "abc" {
+		t.Errorf("object should be abc, but got %q", uploader.object("k"))
+	}
+	decorator.Commit(context.Background())
+	if uploader.puts != 1 {
+		t.Errorf("puts should be 1, but got %d", uploader.puts)
+	}
+}
+
+func TestObjectSyncDecorator_Multipart(t *testing.T) {
+	uploader := newMemoryUploader()
+	decorator := NewObjectSyncDecorator(NewEmptySeekBuffer(), uploader, ObjectSyncConfig{Key: "k", PartSize: 2})
+	decorator.Append([]byte("hello"))
+	if err := decorator.Commit(context.Background()); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if uploader.object("k") != "hello" {
+		t.Errorf("object should be hello, but got %q", uploader.object("k"))
+	}
+	if len(uploader.parts["upload-0"]) != 3 {
+		t.Errorf("parts should be 3, but got %d", len(uploader.parts["upload-0"]))
+	}
+}
+
+func TestObjectSyncDecorator_MultipartFailure(t *testing.T) {
+	uploader := newMemoryUploader()
+	uploader.failAt = 2
+	decorator := NewObjectSyncDecorator(NewSeekBuffer([]byte("hello")), uploader, ObjectSyncConfig{Key: "k", PartSize: 2})
+	if err := decorator.Commit(context.Background()); !errors.Is(err, errTarget) {
+		t.Errorf("error should be errTarget, but got %v", err)
+	}
+	if uploader.aborted != 1 {
+		t.Errorf("aborted should be 1, but got %d", uploader.aborted)
+	}
+	if !decorator.Dirty() {
+		t.Errorf("decorator should stay dirty")
+	}
+}
+
+func TestObjectSyncDecorator_Periodic(t *testing.T) {
+	uploader := newMemoryUploader()
+	decorator := NewObjectSyncDecorator(NewEmptySeekBuffer(), uploader, ObjectSyncConfig{Key: "k", Interval: time.Millisecond})
+	decorator.Write([]byte("abc"))
+	deadline := time.Now().Add(time.Second)
+	for decorator.Dirty() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if uploader.object("k") != "abc" {
+		t.Errorf("object should be abc, but got %q", uploader.object("k"))
+	}
+	decorator.Write([]byte("d"))
+	if err := decorator.Close(); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if uploader.object("k") != "abcd" {
+		t.Errorf("object should be abcd, but got %q", uploader.object("k"))
+	}
+}
+
+func TestObjectSyncDecorator_CloseFailure(t *testing.T) {
+	uploader := newMemoryUploader()
+	uploader.failAt = 2
+	decorator := NewObjectSyncDecorator(NewSeekBuffer([]byte("hello")), uploader, ObjectSyncConfig{Key: "k", PartSize: 2})
+	if err := decorator.Close(); !errors.Is(err, errTarget) {
+		t.Errorf("error should be errTarget, but got %v", err)
+	}
+	if string(decorator.Bytes()) != "hello" {
+		t.Errorf("buffer should stay open after a failed upload, but got %q", decorator.Bytes())
+	}
+	uploader.failAt = 0
+	if err := decorator.Close(); err != nil {
+		t.Errorf("retried close should succeed, but got %v", err)
+	}
+	if uploader.object("k") != "hello" {
+		t.Errorf("object should be hello, but got %q", uploader.object("k"))
+	}
+}
+
+func TestObjectSyncDecorator_ConcurrentClose(t *testing.T) {
+	decorator := NewObjectSyncDecorator(NewEmptySeekBuffer(), newMemoryUploader(), ObjectSyncConfig{Key: "k", Interval: time.Millisecond})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := decorator.Close(); err != nil {
+				t.Errorf("error should be nil, but got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestObjectSyncDecorator_CloseRace(t *testing.T) {
+	uploader := newMemoryUploader()
+	decorator := NewObjectSyncDecorator(NewSeekBuffer([]byte("a")), uploader, ObjectSyncConfig{Key: "k"})
+	uploader.onPut = func() {
+		uploader.onPut = nil
+		// lands between the final upload and closing the buffer
+		decorator.Append([]byte("b"))
+	}
+	if err := decorator.Close(); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if uploader.object("k") != "ab" {
+		t.Errorf("object should include the late write, but got %q", uploader.object("k"))
+	}
+}
@@ -0,0 +1,141 @@
+package seekbuffer
+
+// operation on a buffer
+type Operation int
+
+const (
+	OpRead Operation = iota
+	OpReadBytes
+	OpWrite
+	OpAppend
+	OpSeek
+	OpRewind
+	OpClose
+)
+
+var operationNames = [...]string{"read", "read_bytes", "write", "append", "seek", "rewind", "close"}
+
+func (o Operation) String() string {
+	if o < 0 || int(o) >= len(operationNames) {
+		return "unknown"
+	}
+	return operationNames[o]
+}
+
+// describes a completed operation
+type Event struct {
+	Op Operation
+	// bytes read or written
+	Bytes int
+	// read offset after the operation
+	Offset int
+	Err    error
+}
+
+// callback receiving events
+type Observer func(Event)
+
+// decorator calling registered observers after every operation
+type ObserverDecorator struct {
+	buffer  SeekableBuffer
+	onRead  []Observer
+	onWrite []Observer
+	onSeek  []Observer
+	onClose []Observer
+}
+
+var _ SeekableBuffer = (*ObserverDecorator)(nil)
+
+// wraps buffer without observers
+func NewObserverDecorator(buffer SeekableBuffer) *ObserverDecorator {
+	return &ObserverDecorator{
+		buffer: buffer,
+	}
+}
+
+// registers fn for Read and ReadBytes
+func (d *ObserverDecorator) OnRead(fn Observer) {
+	d.onRead = append(d.onRead, fn)
+}
+
+// registers fn for Write and Append
+func (d *ObserverDecorator) OnWrite(fn Observer) {
+	d.onWrite = append(d.onWrite, fn)
+}
+
+// registers fn for Seek and Rewind
+func (d *ObserverDecorator) OnSeek(fn Observer) {
+	d.onSeek = append(d.onSeek, fn)
+}
+
+// registers fn for Close
+func (d *ObserverDecorator) OnClose(fn Observer) {
+	d.onClose = append(d.onClose, fn)
+}
+
+func (d *ObserverDecorator) notify(observers []Observer, op Operation, n int, err error) {
+	if len(observers) == 0 {
+		return
+	}
+	e := Event{
+		Op:     op,
+		Bytes:  n,
+		Offset: len(d.buffer.Bytes()) - d.buffer.Len(),
+		Err:    err,
+	}
+	for _, fn := range observers {
+		fn(e)
+	}
+}
+
+func (d *ObserverDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+func (d *ObserverDecorator) Append(src []byte) error {
+	err := d.buffer.Append(src)
+	n := len(src)
+	if err != nil {
+		n = 0
+	}
+	d.notify(d.onWrite, OpAppend, n, err)
+	return err
+}
+
+func (d *ObserverDecorator) Write(src []byte) (int, error) {
+	n, err := d.buffer.Write(src)
+	d.notify(d.onWrite, OpWrite, n, err)
+	return n, err
+}
+
+func (d *ObserverDecorator) Read(dst []byte) (int, error) {
+	n, err := d.buffer.Read(dst)
+	d.notify(d.onRead, OpRead, n, err)
+	return n, err
+}
+
+func (d *ObserverDecorator) Rewind() {
+	d.buffer.Rewind()
+	d.notify(d.onSeek, OpRewind, 0, nil)
+}
+
+func (d *ObserverDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+	d.notify(d.onSeek, OpSeek, 0, nil)
+}
+
+func (d *ObserverDecorator) Close() error {
+	err := d.buffer.Close()
+	d.notify(d.onClose, OpClose, 0, err)
+	return err
+}
+
+func (d *ObserverDecorator) ReadBytes(c byte) ([]byte, error) {
+	b, err := d.buffer.ReadBytes(c)
+	d.notify(d.onRead, OpReadBytes, len(b), err)
+	return b, err
+}
+
+func (d *ObserverDecorator) Len() int {
+	return d.buffer.Len()
+}
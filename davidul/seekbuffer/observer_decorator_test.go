@@ -0,0 +1,65 @@
+package seekbuffer
+
+import (
+	"io"
+	"testing"
+)
+
+func TestObserverDecorator(t *testing.T) {
+	decorator := NewObserverDecorator(NewEmptySeekBuffer())
+	var events []Event
+	record := func(e Event) {
+		events = append(events, e)
+	}
+	decorator.OnRead(record)
+	decorator.OnWrite(record)
+	decorator.OnSeek(record)
+	decorator.OnClose(record)
+
+	decorator.Write([]byte("abc\nd"))
+	decorator.Seek(1)
+	decorator.ReadBytes('\n')
+	decorator.Read(make([]byte, 4))
+	decorator.Read(make([]byte, 4))
+	decorator.Rewind()
+	decorator.Close()
+
+	expected := []Event{
+		{Op: OpWrite, Bytes: 5, Offset: 0},
+		{Op: OpSeek, Offset: 1},
+		{Op: OpReadBytes, Bytes: 3, Offset: 4},
+		{Op: OpRead, Bytes: 1, Offset: 5},
+		{Op: OpRead, Offset: 5, Err: io.EOF},
+		{Op: OpRewind, Offset: 0},
+		{Op: OpClose, Offset: 0},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("events should be %d, but got %d", len(expected), len(events))
+	}
+	for i, e := range expected {
+		if events[i] != e {
+			t.Errorf("event %d should be %+v, but got %+v", i, e, events[i])
+		}
+	}
+}
+
+func TestObserverDecorator_FailedAppend(t *testing.T) {
+	decorator := NewObserverDecorator(NewSeekBufferWithLimit(1))
+	var event Event
+	decorator.OnWrite(func(e Event) {
+		event = e
+	})
+	decorator.Append([]byte("ab"))
+	if event.Op != OpAppend || event.Bytes != 0 || event.Err != ErrBufferFull {
+		t.Errorf("event should report the failed append, but got %+v", event)
+	}
+}
+
+func TestOperationString(t *testing.T) {
+	if OpReadBytes.String() != "read_bytes" {
+		t.Errorf("name should be read_bytes, but got %q", OpReadBytes.String())
+	}
+	if Operation(42).String() != "unknown" {
+		t.Errorf("name should be unknown, but got %q", Operation(42).String())
+	}
+}
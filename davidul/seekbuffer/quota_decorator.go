@@ -0,0 +1,147 @@
+package seekbuffer
+
+import (
+	"errors"
+)
+
+// what QuotaDecorator does with a write exceeding the quota
+type QuotaPolicy int
+
+const (
+	// the write fails with ErrBufferFull
+	QuotaReject QuotaPolicy = iota
+	// the oldest bytes are dropped to make room, the wrapped buffer
+	// must support Compact
+	QuotaDropOldest
+	// the write is handed to the spill callback instead of the buffer
+	QuotaSpill
+)
+
+// configures QuotaDecorator
+type QuotaConfig struct {
+	// maximum number of bytes stored in the wrapped buffer
+	MaxSize int
+	Policy  QuotaPolicy
+	// receives writes not fitting the quota under QuotaSpill, its error
+	// is returned by the write
+	Spill func(src []byte) error
+}
+
+// buffers whose consumed prefix can be released
+type compacter interface {
+	Compact() int
+}
+
+// decorator enforcing a maximum stored size over the wrapped buffer
+type QuotaDecorator struct {
+	buffer  SeekableBuffer
+	config  QuotaConfig
+	dropped int
+	spilled int
+}
+
+var _ SeekableBuffer = (*QuotaDecorator)(nil)
+
+// wraps buffer, fails if the policy cannot be applied to it
+func NewQuotaDecorator(buffer SeekableBuffer, config QuotaConfig) (*QuotaDecorator, error) {
+	if config.MaxSize <= 0 {
+		return nil, errors.New("seekbuffer: quota must be positive")
+	}
+	if _, ok := buffer.(compacter); config.Policy == QuotaDropOldest && !ok {
+		return nil, errors.New("seekbuffer: drop-oldest quota needs a buffer with Compact")
+	}
+	if config.Policy == QuotaSpill && config.Spill == nil {
+		return nil, errors.New("seekbuffer: spill quota needs a spill callback")
+	}
+	return &QuotaDecorator{
+		buffer: buffer,
+		config: config,
+	}, nil
+}
+
+// number of bytes dropped under QuotaDropOldest
+func (d *QuotaDecorator) Dropped() int {
+	return d.dropped
+}
+
+// number of bytes handed to the spill callback
+func (d *QuotaDecorator) Spilled() int {
+	return d.spilled
+}
+
+// makes room for src, reports false when src went to the spill callback
+func (d *QuotaDecorator) admit(src []byte) (bool, error) {
+	size := len(d.buffer.Bytes())
+	excess := size + len(src) - d.config.MaxSize
+	if excess <= 0 {
+		return true, nil
+	}
+	switch d.config.Policy {
+	case QuotaDropOldest:
+		if len(src) > d.config.MaxSize {
+			return false, ErrBufferFull
+		}
+		offset := size - d.buffer.Len()
+		d.buffer.Seek(excess)
+		d.buffer.(compacter).Compact()
+		d.buffer.Seek(max(0, offset-excess))
+		d.dropped += excess
+		return true, nil
+	case QuotaSpill:
+		if err := d.config.Spill(src); err != nil {
+			return false, err
+		}
+		d.spilled += len(src)
+		return false, nil
+	}
+	return false, ErrBufferFull
+}
+
+func (d *QuotaDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+// appends content within the quota
+func (d *QuotaDecorator) Append(src []byte) error {
+	ok, err := d.admit(src)
+	if !ok || err != nil {
+		return err
+	}
+	return d.buffer.Append(src)
+}
+
+// writes content within the quota, spilled content counts as written
+func (d *QuotaDecorator) Write(src []byte) (int, error) {
+	ok, err := d.admit(src)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return len(src), nil
+	}
+	return d.buffer.Write(src)
+}
+
+func (d *QuotaDecorator) Read(dst []byte) (int, error) {
+	return d.buffer.Read(dst)
+}
+
+func (d *QuotaDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *QuotaDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+func (d *QuotaDecorator) Close() error {
+	return d.buffer.Close()
+}
+
+func (d *QuotaDecorator) ReadBytes(c byte) ([]byte, error) {
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *QuotaDecorator) Len() int {
+	return d.buffer.Len()
+}
@@ -0,0 +1,76 @@
+package seekbuffer
+
+import (
+	"testing"
+)
+
+func TestQuotaDecorator_Reject(t *testing.T) {
+	decorator, _ := NewQuotaDecorator(NewEmptySeekBuffer(), QuotaConfig{MaxSize: 4})
+	if _, err := decorator.Write([]byte("abc")); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if err := decorator.Append([]byte("de")); err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+	if len(decorator.Bytes()) != 3 {
+		t.Errorf("len should be 3, but got %d", len(decorator.Bytes()))
+	}
+}
+
+func TestQuotaDecorator_DropOldest(t *testing.T) {
+	buffer := NewEmptySeekBuffer()
+	decorator, err := NewQuotaDecorator(buffer, QuotaConfig{MaxSize: 4, Policy: QuotaDropOldest})
+	if err != nil {
+		t.Fatalf("error should be nil, but got %v", err)
+	}
+	decorator.Write([]byte("abc"))
+	decorator.Seek(2)
+	decorator.Write([]byte("def"))
+	if string(decorator.Bytes()) != "cdef" {
+		t.Errorf("content should be cdef, but got %q", decorator.Bytes())
+	}
+	if decorator.Dropped() != 2 {
+		t.Errorf("dropped should be 2, but got %d", decorator.Dropped())
+	}
+	if decorator.Len() != 4 {
+		t.Errorf("len should be 4, but got %d", decorator.Len())
+	}
+	if err := decorator.Append([]byte("toolong")); err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+}
+
+func TestQuotaDecorator_Spill(t *testing.T) {
+	var spilled []byte
+	decorator, _ := NewQuotaDecorator(NewEmptySeekBuffer(), QuotaConfig{
+		MaxSize: 2,
+		Policy:  QuotaSpill,
+		Spill: func(src []byte) error {
+			spilled = append(spilled, src...)
+			return nil
+		},
+	})
+	decorator.Write([]byte("ab"))
+	n, err := decorator.Write([]byte("cd"))
+	if err != nil || n != 2 {
+		t.Errorf("write should succeed, but got %d, %v", n, err)
+	}
+	if string(spilled) != "cd" || decorator.Spilled() != 2 {
+		t.Errorf("spilled should be cd, but got %q", spilled)
+	}
+	if string(decorator.Bytes()) != "ab" {
+		t.Errorf("content should be ab, but got %q", decorator.Bytes())
+	}
+}
+
+func TestQuotaDecorator_Config(t *testing.T) {
+	if _, err := NewQuotaDecorator(NewEmptySeekBuffer(), QuotaConfig{}); err == nil {
+		t.Errorf("error should not be nil for a zero quota")
+	}
+	if _, err := NewQuotaDecorator(NewChunkedSeekBuffer(4), QuotaConfig{MaxSize: 1, Policy: QuotaDropOldest}); err == nil {
+		t.Errorf("error should not be nil without Compact")
+	}
+	if _, err := NewQuotaDecorator(NewEmptySeekBuffer(), QuotaConfig{MaxSize: 1, Policy: QuotaSpill}); err == nil {
+		t.Errorf("error should not be nil without a spill callback")
+	}
+}
@@ -0,0 +1,163 @@
+package seekbuffer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// token bucket refilled with rate tokens per second up to burst tokens
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// takes n tokens, blocking until at least min(n, burst) of them are
+// available; the rest becomes debt paid by later calls
+func (b *tokenBucket) wait(ctx context.Context, n int) error {
+	if n <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	b.refill(time.Now())
+	need := min(float64(n), b.burst)
+	delay := time.Duration(0)
+	if b.tokens < need {
+		delay = time.Duration((need - b.tokens) / b.rate * float64(time.Second))
+	}
+	b.tokens -= float64(n)
+	b.mu.Unlock()
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.refund(n)
+		return ctx.Err()
+	}
+}
+
+// returns n unused tokens
+func (b *tokenBucket) refund(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	b.tokens = min(b.burst, b.tokens+float64(n))
+}
+
+// decorator limiting read and write throughput of the wrapped buffer,
+// reads and writes have separate budgets
+type RateLimitDecorator struct {
+	buffer SeekableBuffer
+	read   *tokenBucket
+	write  *tokenBucket
+}
+
+var _ ContextBuffer = (*RateLimitDecorator)(nil)
+
+// wraps buffer allowing bytesPerSec bytes per second in each direction
+// with bursts of up to burst bytes, burst defaults to bytesPerSec, fails
+// unless bytesPerSec is positive
+func NewRateLimitDecorator(buffer SeekableBuffer, bytesPerSec float64, burst int) (*RateLimitDecorator, error) {
+	if !(bytesPerSec > 0) {
+		return nil, errors.New("seekbuffer: rate must be positive")
+	}
+	if burst <= 0 {
+		burst = max(1, int(bytesPerSec))
+	}
+	return &RateLimitDecorator{
+		buffer: buffer,
+		read:   newTokenBucket(bytesPerSec, burst),
+		write:  newTokenBucket(bytesPerSec, burst),
+	}, nil
+}
+
+func (d *RateLimitDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+// appends content once the write budget allows it
+func (d *RateLimitDecorator) Append(src []byte) error {
+	_, err := d.WriteContext(context.Background(), src)
+	return err
+}
+
+// writes content once the write budget allows it
+func (d *RateLimitDecorator) Write(src []byte) (int, error) {
+	return d.WriteContext(context.Background(), src)
+}
+
+// writes content once the write budget allows it or fails when ctx is done
+func (d *RateLimitDecorator) WriteContext(ctx context.Context, src []byte) (int, error) {
+	if err := d.write.wait(ctx, len(src)); err != nil {
+		return 0, err
+	}
+	n, err := d.buffer.Write(src)
+	d.write.refund(len(src) - n)
+	return n, err
+}
+
+// reads at most a burst of content once the read budget allows it
+func (d *RateLimitDecorator) Read(dst []byte) (int, error) {
+	return d.ReadContext(context.Background(), dst)
+}
+
+// reads at most a burst of content once the read budget allows it or
+// fails when ctx is done
+func (d *RateLimitDecorator) ReadContext(ctx context.Context, dst []byte) (int, error) {
+	want := min(len(dst), d.buffer.Len(), int(d.read.burst))
+	if want <= 0 {
+		return d.buffer.Read(dst[:0:0])
+	}
+	if err := d.read.wait(ctx, want); err != nil {
+		return 0, err
+	}
+	n, err := d.buffer.Read(dst[:want])
+	d.read.refund(want - n)
+	return n, err
+}
+
+func (d *RateLimitDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *RateLimitDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+func (d *RateLimitDecorator) Close() error {
+	return d.buffer.Close()
+}
+
+// reads up to c, the bytes returned are charged to the read budget
+func (d *RateLimitDecorator) ReadBytes(c byte) ([]byte, error) {
+	b, err := d.buffer.ReadBytes(c)
+	d.read.wait(context.Background(), len(b))
+	return b, err
+}
+
+func (d *RateLimitDecorator) Len() int {
+	return d.buffer.Len()
+}
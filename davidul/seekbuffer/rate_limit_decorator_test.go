@@ -0,0 +1,71 @@
+package seekbuffer
+
+import (
+	"context"
+	"io"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRateLimitDecorator_Write(t *testing.T) {
+	decorator, err := NewRateLimitDecorator(NewEmptySeekBuffer(), 1000, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	decorator.Write(make([]byte, 100))
+	if time.Since(start) > 50*time.Millisecond {
+		t.Errorf("burst should not block, but took %v", time.Since(start))
+	}
+	decorator.Write(make([]byte, 100))
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("second write should wait about 100ms, but took %v", elapsed)
+	}
+	if decorator.Len() != 200 {
+		t.Errorf("len should be 200, but got %d", decorator.Len())
+	}
+}
+
+func TestRateLimitDecorator_Read(t *testing.T) {
+	decorator, err := NewRateLimitDecorator(NewSeekBuffer(make([]byte, 10)), 1000, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := make([]byte, 10)
+	n, err := decorator.Read(dst)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if n != 4 {
+		t.Errorf("read should be limited to the burst of 4, but got %d", n)
+	}
+	decorator.Seek(10)
+	if _, err := decorator.Read(dst); err != io.EOF {
+		t.Errorf("error should be EOF, but got %v", err)
+	}
+}
+
+func TestRateLimitDecorator_Context(t *testing.T) {
+	decorator, err := NewRateLimitDecorator(NewEmptySeekBuffer(), 10, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decorator.Write(make([]byte, 10))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := decorator.WriteContext(ctx, make([]byte, 10)); err != context.DeadlineExceeded {
+		t.Errorf("error should be DeadlineExceeded, but got %v", err)
+	}
+	if decorator.Len() != 10 {
+		t.Errorf("len should be 10, but got %d", decorator.Len())
+	}
+}
+
+func TestRateLimitDecorator_InvalidRate(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.NaN()} {
+		if _, err := NewRateLimitDecorator(NewEmptySeekBuffer(), rate, 1); err == nil {
+			t.Errorf("rate %v should be rejected", rate)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package seekbuffer
+
+// decorator exposing the wrapped buffer for reading only, writes and
+// Close fail with ErrReadOnly
+type ReadOnlyDecorator struct {
+	buffer SeekableBuffer
+}
+
+var _ SeekableBuffer = (*ReadOnlyDecorator)(nil)
+
+// wraps buffer so it cannot be modified through the decorator
+func NewReadOnlyDecorator(buffer SeekableBuffer) *ReadOnlyDecorator {
+	return &ReadOnlyDecorator{
+		buffer: buffer,
+	}
+}
+
+// returns a copy of the content so it cannot be changed through the slice
+func (d *ReadOnlyDecorator) Bytes() []byte {
+	content := d.buffer.Bytes()
+	b := make([]byte, len(content))
+	copy(b, content)
+	return b
+}
+
+func (d *ReadOnlyDecorator) Append(src []byte) error {
+	return ErrReadOnly
+}
+
+func (d *ReadOnlyDecorator) Write(src []byte) (int, error) {
+	return 0, ErrReadOnly
+}
+
+func (d *ReadOnlyDecorator) Read(dst []byte) (int, error) {
+	return d.buffer.Read(dst)
+}
+
+func (d *ReadOnlyDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *ReadOnlyDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+// the wrapped buffer stays open, its owner closes it
+func (d *ReadOnlyDecorator) Close() error {
+	return ErrReadOnly
+}
+
+func (d *ReadOnlyDecorator) ReadBytes(c byte) ([]byte, error) {
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *ReadOnlyDecorator) Len() int {
+	return d.buffer.Len()
+}
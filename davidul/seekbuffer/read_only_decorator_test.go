@@ -0,0 +1,40 @@
+package seekbuffer
+
+import (
+	"testing"
+)
+
+func TestReadOnlyDecorator(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("abc\ndef"))
+	decorator := NewReadOnlyDecorator(buffer)
+	if err := decorator.Append([]byte("x")); err != ErrReadOnly {
+		t.Errorf("error should be ErrReadOnly, but got %v", err)
+	}
+	if _, err := decorator.Write([]byte("x")); err != ErrReadOnly {
+		t.Errorf("error should be ErrReadOnly, but got %v", err)
+	}
+	if err := decorator.Close(); err != ErrReadOnly {
+		t.Errorf("error should be ErrReadOnly, but got %v", err)
+	}
+	decorator.Bytes()[0] = 'x'
+	if buffer.buffer[0] != 'a' {
+		t.Errorf("buffer should not change through Bytes")
+	}
+	line, _ := decorator.ReadBytes('\n')
+	if string(line) != "abc\n" {
+		t.Errorf("line should be abc, but got %q", line)
+	}
+	decorator.Seek(5)
+	if decorator.Len() != 2 {
+		t.Errorf("len should be 2, but got %d", decorator.Len())
+	}
+	decorator.Rewind()
+	dst := make([]byte, 2)
+	n, _ := decorator.Read(dst)
+	if string(dst[:n]) != "ab" {
+		t.Errorf("read should be ab, but got %q", dst[:n])
+	}
+	if buffer.String() != "c\ndef" {
+		t.Errorf("buffer should be c def, but got %q", buffer.String())
+	}
+}
@@ -0,0 +1,75 @@
+package seekbuffer
+
+import (
+	"errors"
+	"io"
+)
+
+// size of the chunks read by NewSeekBufferFromReader
+const readChunkSize = 32 * 1024
+
+// buffer with the content read from r until EOF, fails with ErrBufferFull
+// when r holds more than maxBytes, 0 means no limit
+func NewSeekBufferFromReader(r io.Reader, maxBytes int64) (*SeekBuffer, error) {
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
+	size := remaining(r)
+	if maxBytes > 0 && size > maxBytes {
+		return nil, ErrBufferFull
+	}
+	a := make([]byte, 0, size)
+	var chunk []byte
+	for {
+		var n int
+		var err error
+		if len(a) < cap(a) {
+			n, err = r.Read(a[len(a):cap(a)])
+			a = a[:len(a)+n]
+		} else {
+			// the preallocated space is used up, read into a scratch chunk
+			// so that a final empty read does not grow the buffer
+			if chunk == nil {
+				chunk = make([]byte, readChunkSize)
+			}
+			n, err = r.Read(chunk)
+			a = append(a, chunk[:n]...)
+		}
+		if maxBytes > 0 && int64(len(a)) > maxBytes {
+			return nil, ErrBufferFull
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &SeekBuffer{buffer: a}, nil
+}
+
+// returns the number of bytes left in r when it can be known upfront,
+// 0 when it is unknown
+func remaining(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		// a SeekBuffer sought past its end reports a negative length
+		return int64(max(0, v.Len()))
+	case io.Seeker:
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0
+		}
+		if _, err := v.Seek(cur, io.SeekStart); err != nil {
+			return 0
+		}
+		if end > cur {
+			return end - cur
+		}
+	}
+	return 0
+}
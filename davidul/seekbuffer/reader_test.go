@@ -0,0 +1,60 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNewSeekBufferFromReader(t *testing.T) {
+	src := bytes.Repeat([]byte{1, 2, 3}, readChunkSize)
+	buffer, err := NewSeekBufferFromReader(io.MultiReader(bytes.NewReader(src)), 0)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if !bytes.Equal(buffer.Bytes(), src) {
+		t.Errorf("content should match, but got %d bytes", len(buffer.Bytes()))
+	}
+	if buffer.offset != 0 {
+		t.Errorf("offset should be 0, but got %d", buffer.offset)
+	}
+}
+
+func TestNewSeekBufferFromReader_Preallocate(t *testing.T) {
+	r := strings.NewReader("hello")
+	r.ReadByte()
+	buffer, err := NewSeekBufferFromReader(r, 4)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if buffer.String() != "ello" {
+		t.Errorf("content should be ello, but got %q", buffer.String())
+	}
+	if cap(buffer.buffer) != 4 {
+		t.Errorf("cap should be 4, but got %d", cap(buffer.buffer))
+	}
+}
+
+func TestNewSeekBufferFromReader_Limit(t *testing.T) {
+	_, err := NewSeekBufferFromReader(strings.NewReader("hello"), 4)
+	if err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+	_, err = NewSeekBufferFromReader(io.MultiReader(strings.NewReader("hello")), 4)
+	if err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+}
+
+func TestNewSeekBufferFromReader_PastEnd(t *testing.T) {
+	src := NewSeekBuffer([]byte("abc"))
+	src.Seek(10)
+	buffer, err := NewSeekBufferFromReader(src, 0)
+	if err != nil {
+		t.Fatalf("error should be nil, but got %v", err)
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("buffer should be empty, but got %q", buffer.Bytes())
+	}
+}
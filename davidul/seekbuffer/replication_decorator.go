@@ -0,0 +1,252 @@
+package seekbuffer
+
+import (
+	"errors"
+	"sync"
+)
+
+// returned by Promote and Lag for buffers which are not replicas
+var ErrUnknownReplica = errors.New("seekbuffer: unknown replica")
+
+// a secondary buffer with the writes still to be applied to it
+type replica struct {
+	buffer SeekableBuffer
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  [][]byte
+	// bytes queued and not applied yet
+	lag int
+	// first error of the secondary, replication to it stops
+	err     error
+	stopped bool
+	done    chan struct{}
+}
+
+func newReplica(buffer SeekableBuffer) *replica {
+	r := &replica{
+		buffer: buffer,
+		done:   make(chan struct{}),
+	}
+	r.cond = sync.NewCond(&r.mu)
+	go r.loop()
+	return r
+}
+
+// applies queued writes until stopped or failed
+func (r *replica) loop() {
+	defer close(r.done)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for {
+		for len(r.queue) == 0 && !r.stopped {
+			r.cond.Wait()
+		}
+		if r.stopped || r.err != nil {
+			return
+		}
+		data := r.queue[0]
+		r.mu.Unlock()
+		err := r.buffer.Append(data)
+		r.mu.Lock()
+		if err != nil {
+			r.err = err
+		} else {
+			r.queue = r.queue[1:]
+			r.lag -= len(data)
+		}
+		r.cond.Broadcast()
+	}
+}
+
+func (r *replica) push(data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.queue = append(r.queue, data)
+	r.lag += len(data)
+	r.cond.Broadcast()
+}
+
+// stops the loop and applies the remaining writes synchronously
+func (r *replica) drain() error {
+	r.mu.Lock()
+	r.stopped = true
+	r.cond.Broadcast()
+	r.mu.Unlock()
+	<-r.done
+	for r.err == nil && len(r.queue) > 0 {
+		if r.err = r.buffer.Append(r.queue[0]); r.err == nil {
+			r.lag -= len(r.queue[0])
+			r.queue = r.queue[1:]
+		}
+	}
+	return r.err
+}
+
+// decorator applying writes to a primary buffer and replicating them
+// asynchronously to secondaries, safe for concurrent use
+type ReplicationDecorator struct {
+	mu       sync.Mutex
+	primary  SeekableBuffer
+	replicas []*replica
+}
+
+var _ SeekableBuffer = (*ReplicationDecorator)(nil)
+
+// wraps primary, the secondaries should hold the same content as primary
+// and receive every write made through the decorator
+func NewReplicationDecorator(primary SeekableBuffer, secondaries ...SeekableBuffer) *ReplicationDecorator {
+	d := &ReplicationDecorator{primary: primary}
+	for _, s := range secondaries {
+		d.replicas = append(d.replicas, newReplica(s))
+	}
+	return d
+}
+
+func (d *ReplicationDecorator) find(secondary SeekableBuffer) (int, error) {
+	for i, r := range d.replicas {
+		if r.buffer == secondary {
+			return i, nil
+		}
+	}
+	return -1, ErrUnknownReplica
+}
+
+// returns the current primary
+func (d *ReplicationDecorator) Primary() SeekableBuffer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.primary
+}
+
+// returns the number of bytes written to the primary and not yet to secondary,
+// and the error which stopped replication to it
+func (d *ReplicationDecorator) Lag(secondary SeekableBuffer) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i, err := d.find(secondary)
+	if err != nil {
+		return 0, err
+	}
+	r := d.replicas[i]
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lag, r.err
+}
+
+// blocks until every secondary caught up or failed, returns their errors
+func (d *ReplicationDecorator) Sync() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var errs []error
+	for _, r := range d.replicas {
+		r.mu.Lock()
+		for len(r.queue) > 0 && r.err == nil {
+			r.cond.Wait()
+		}
+		errs = append(errs, r.err)
+		r.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// makes secondary the primary once it caught up, the old primary is
+// dropped and the read offset carries over
+func (d *ReplicationDecorator) Promote(secondary SeekableBuffer) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i, err := d.find(secondary)
+	if err != nil {
+		return err
+	}
+	r := d.replicas[i]
+	if err := r.drain(); err != nil {
+		return err
+	}
+	offset := len(d.primary.Bytes()) - d.primary.Len()
+	d.replicas = append(d.replicas[:i], d.replicas[i+1:]...)
+	d.primary = secondary
+	d.primary.Seek(offset)
+	return nil
+}
+
+// queues data for every secondary
+func (d *ReplicationDecorator) replicate(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	data = append([]byte(nil), data...)
+	for _, r := range d.replicas {
+		r.push(data)
+	}
+}
+
+func (d *ReplicationDecorator) Bytes() []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.primary.Bytes()
+}
+
+// appends to the primary and replicates on success
+func (d *ReplicationDecorator) Append(src []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.primary.Append(src); err != nil {
+		return err
+	}
+	d.replicate(src)
+	return nil
+}
+
+// writes to the primary and replicates what was written
+func (d *ReplicationDecorator) Write(src []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n, err := d.primary.Write(src)
+	d.replicate(src[:n])
+	return n, err
+}
+
+func (d *ReplicationDecorator) Read(dst []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.primary.Read(dst)
+}
+
+func (d *ReplicationDecorator) Rewind() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.primary.Rewind()
+}
+
+func (d *ReplicationDecorator) Seek(offset int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.primary.Seek(offset)
+}
+
+// applies pending writes to the secondaries and closes all buffers
+func (d *ReplicationDecorator) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	errs := []error{d.primary.Close()}
+	for _, r := range d.replicas {
+		errs = append(errs, r.drain(), r.buffer.Close())
+	}
+	d.replicas = nil
+	return errors.Join(errs...)
+}
+
+func (d *ReplicationDecorator) ReadBytes(c byte) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.primary.ReadBytes(c)
+}
+
+func (d *ReplicationDecorator) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.primary.Len()
+}
@@ -0,0 +1,68 @@
+package seekbuffer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReplicationDecorator_Replicates(t *testing.T) {
+	primary, a, b := NewEmptySeekBuffer(), NewEmptySeekBuffer(), NewEmptySeekBuffer()
+	decorator := NewReplicationDecorator(primary, a, b)
+	decorator.Write([]byte("hello "))
+	decorator.Append([]byte("world"))
+	if err := decorator.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []*SeekBuffer{a, b} {
+		if string(s.Bytes()) != "hello world" {
+			t.Errorf("secondary should hold hello world, but got %q", s.Bytes())
+		}
+		if lag, err := decorator.Lag(s); lag != 0 || err != nil {
+			t.Errorf("lag should be 0, but got %d, %v", lag, err)
+		}
+	}
+	if _, err := decorator.Lag(primary); err != ErrUnknownReplica {
+		t.Errorf("primary should not be a replica, but got %v", err)
+	}
+}
+
+func TestReplicationDecorator_Promote(t *testing.T) {
+	primary, secondary := NewSeekBufferWithLimit(8), NewEmptySeekBuffer()
+	decorator := NewReplicationDecorator(primary, secondary)
+	decorator.Write([]byte("12345678"))
+	decorator.Seek(3)
+	if _, err := decorator.Write([]byte("9")); err != ErrBufferFull {
+		t.Fatalf("primary should be full, but got %v", err)
+	}
+	if err := decorator.Promote(secondary); err != nil {
+		t.Fatal(err)
+	}
+	if decorator.Primary() != SeekableBuffer(secondary) {
+		t.Errorf("secondary should be the primary")
+	}
+	if _, err := decorator.Write([]byte("9")); err != nil {
+		t.Errorf("write should go to the new primary, but got %v", err)
+	}
+	if string(decorator.Bytes()) != "123456789" || decorator.Len() != 6 {
+		t.Errorf("content and offset should carry over, but got %q with %d unread", decorator.Bytes(), decorator.Len())
+	}
+	if err := decorator.Promote(secondary); err != ErrUnknownReplica {
+		t.Errorf("promoted buffer should no longer be a replica, but got %v", err)
+	}
+}
+
+func TestReplicationDecorator_FailedSecondary(t *testing.T) {
+	secondary := NewSeekBufferWithLimit(2)
+	decorator := NewReplicationDecorator(NewEmptySeekBuffer(), secondary)
+	decorator.Write([]byte("abc"))
+	if err := decorator.Sync(); !errors.Is(err, ErrBufferFull) {
+		t.Errorf("sync should report the secondary failure, but got %v", err)
+	}
+	decorator.Write([]byte("d"))
+	if lag, err := decorator.Lag(secondary); lag != 3 || !errors.Is(err, ErrBufferFull) {
+		t.Errorf("lag should keep the failed write and report the failure, but got %d, %v", lag, err)
+	}
+	if err := decorator.Promote(secondary); !errors.Is(err, ErrBufferFull) {
+		t.Errorf("failed secondary should not be promoted, but got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package seekbuffer
+
+import (
+	"bufio"
+	"io"
+)
+
+// number of tokens in a row without progress before Scan gives up
+const maxEmptyTokens = 100
+
+// tokenizes the unread content of a SeekBuffer with a bufio.SplitFunc,
+// the offset of the buffer advances with every token
+type Scanner struct {
+	buffer *SeekBuffer
+	split  bufio.SplitFunc
+	token  []byte
+	err    error
+	done   bool
+	// tokens returned in a row without advancing
+	empties int
+}
+
+// returns a scanner over the unread content using split, for example
+// bufio.ScanLines or bufio.ScanWords
+func (s *SeekBuffer) Scanner(split bufio.SplitFunc) *Scanner {
+	return &Scanner{
+		buffer: s,
+		split:  split,
+	}
+}
+
+// advances to the next token, returns false at the end of the content
+// or on error
+func (sc *Scanner) Scan() bool {
+	sc.token = nil
+	if sc.done {
+		return false
+	}
+	for {
+		data := sc.buffer.unread()
+		// the whole content is in memory, so every call is at EOF
+		advance, token, err := sc.split(data, true)
+		if err != nil && err != bufio.ErrFinalToken {
+			sc.err = err
+			sc.done = true
+			return false
+		}
+		if advance < 0 {
+			sc.err = bufio.ErrNegativeAdvance
+			sc.done = true
+			return false
+		}
+		if advance > len(data) {
+			sc.err = bufio.ErrAdvanceTooFar
+			sc.done = true
+			return false
+		}
+		sc.buffer.offset += advance
+		sc.buffer.lastRead = opInvalid
+		if err == bufio.ErrFinalToken {
+			sc.token = token
+			sc.done = true
+			return token != nil
+		}
+		if token != nil {
+			if advance > 0 {
+				sc.empties = 0
+			} else if sc.empties++; sc.empties > maxEmptyTokens {
+				sc.err = io.ErrNoProgress
+				sc.done = true
+				return false
+			}
+			sc.token = token
+			return true
+		}
+		if advance == 0 {
+			sc.done = true
+			return false
+		}
+	}
+}
+
+// returns the most recent token
+func (sc *Scanner) Bytes() []byte {
+	return sc.token
+}
+
+// returns the most recent token as a string
+func (sc *Scanner) Text() string {
+	return string(sc.token)
+}
+
+// returns the first error encountered by Scan
+func (sc *Scanner) Err() error {
+	return sc.err
+}
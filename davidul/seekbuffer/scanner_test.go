@@ -0,0 +1,82 @@
+package seekbuffer
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestScanner_Words(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("  one two\nthree "))
+	scanner := buffer.Scanner(bufio.ScanWords)
+	if !scanner.Scan() {
+		t.Errorf("scan should succeed")
+	}
+	if scanner.Text() != "one" {
+		t.Errorf("token should be one, but got %q", scanner.Text())
+	}
+	if buffer.offset != 6 {
+		t.Errorf("offset should be 6, but got %d", buffer.offset)
+	}
+	var words []string
+	for scanner.Scan() {
+		words = append(words, scanner.Text())
+	}
+	if len(words) != 2 || words[1] != "three" {
+		t.Errorf("words should be two and three, but got %v", words)
+	}
+	if scanner.Err() != nil {
+		t.Errorf("error should be nil, but got %v", scanner.Err())
+	}
+}
+
+func TestScanner_Lines(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("a\nb"))
+	scanner := buffer.Scanner(bufio.ScanLines)
+	count := 0
+	for scanner.Scan() {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("lines should be 2, but got %d", count)
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("len should be 0, but got %d", buffer.Len())
+	}
+}
+
+func TestScanner_Error(t *testing.T) {
+	fail := errors.New("fail")
+	buffer := NewSeekBuffer([]byte("abc"))
+	scanner := buffer.Scanner(func(data []byte, atEOF bool) (int, []byte, error) {
+		if len(data) < 2 {
+			return 0, nil, fail
+		}
+		return 1, data[:1], nil
+	})
+	for scanner.Scan() {
+	}
+	if scanner.Err() != fail {
+		t.Errorf("error should be fail, but got %v", scanner.Err())
+	}
+	if buffer.offset != 2 {
+		t.Errorf("offset should be 2, but got %d", buffer.offset)
+	}
+}
+
+func TestScanner_NoProgress(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("abc"))
+	scanner := buffer.Scanner(func(data []byte, atEOF bool) (int, []byte, error) {
+		return 0, []byte{}, nil
+	})
+	calls := 0
+	for scanner.Scan() {
+		if calls++; calls > 2*maxEmptyTokens {
+			t.Fatalf("scan should stop without progress")
+		}
+	}
+	if scanner.Err() != io.ErrNoProgress {
+		t.Errorf("error should be io.ErrNoProgress, but got %v", scanner.Err())
+	}
+}
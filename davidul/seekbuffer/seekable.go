@@ -0,0 +1,40 @@
+package seekbuffer
+
+import (
+	"context"
+)
+
+// buffer with a read offset which can be moved around
+type SeekableBuffer interface {
+	Bytes() []byte
+	Append(src []byte) error
+	Write(src []byte) (int, error)
+	Read(dst []byte) (int, error)
+	Rewind()
+	Seek(offset int)
+	Close() error
+	ReadBytes(c byte) ([]byte, error)
+	Len() int
+}
+
+var _ SeekableBuffer = (*SeekBuffer)(nil)
+
+// returns the unread content of any SeekableBuffer
+func unreadBytes(b SeekableBuffer) []byte {
+	content := b.Bytes()
+	n := b.Len()
+	if n <= 0 {
+		return nil
+	}
+	return content[len(content)-n:]
+}
+
+// buffer whose reads and writes honor cancellation and deadlines
+type ContextBuffer interface {
+	SeekableBuffer
+	ReadContext(ctx context.Context, dst []byte) (int, error)
+	WriteContext(ctx context.Context, src []byte) (int, error)
+}
+
+var _ ContextBuffer = (*SeekBuffer)(nil)
+var _ ContextBuffer = (*ChunkedSeekBuffer)(nil)
@@ -2,13 +2,48 @@ package seekbuffer
 
 import (
 	"bytes"
+	"errors"
 	"io"
+	"unicode/utf8"
+)
+
+// returned when a write would exceed the maximum size of the buffer
+var ErrBufferFull = errors.New("seekbuffer: buffer full")
+
+// returned when writing to a read-only view
+var ErrReadOnly = errors.New("seekbuffer: read-only buffer")
+
+// returned when a range is outside of the buffer
+var ErrOutOfRange = errors.New("seekbuffer: range out of bounds")
+
+// returned when UnreadByte does not follow a successful read
+var ErrInvalidUnreadByte = errors.New("seekbuffer: invalid use of UnreadByte")
+
+// returned when UnreadRune does not follow a successful ReadRune
+var ErrInvalidUnreadRune = errors.New("seekbuffer: invalid use of UnreadRune")
+
+// kind of the last read operation, positive values are the size of the
+// rune returned by ReadRune
+const (
+	opInvalid = 0
+	opRead    = -1
 )
 
 // byte buffer and pointer to the current offset
 type SeekBuffer struct {
-	buffer []byte
-	offset int
+	buffer  []byte
+	offset  int
+	maxSize int
+	// views refuse writes
+	readOnly bool
+	// Bytes returns a copy instead of the internal storage
+	safe bool
+	// MarshalJSON emits valid UTF-8 content as text
+	jsonText bool
+	// last read operation, used by UnreadByte and UnreadRune
+	lastRead int
+	// incremented on every change of the content
+	version uint64
 }
 
 // empty buffer
@@ -29,64 +64,393 @@ func NewSeekBuffer(src []byte) *SeekBuffer {
 	}
 }
 
-// returns current content of the buffer
+// empty buffer with room for n bytes before it has to grow
+func NewSeekBufferWithCapacity(n int) *SeekBuffer {
+	if n < 0 {
+		n = 0
+	}
+	return &SeekBuffer{
+		buffer: make([]byte, 0, n),
+		offset: 0,
+	}
+}
+
+// buffer with initial content whose Bytes returns a copy, so callers
+// cannot change the buffer through the returned slice
+func NewSafeSeekBuffer(src []byte) *SeekBuffer {
+	b := NewSeekBuffer(src)
+	b.safe = true
+	return b
+}
+
+// empty buffer which holds at most maxSize bytes, 0 means no limit
+func NewSeekBufferWithLimit(maxSize int) *SeekBuffer {
+	b := NewEmptySeekBuffer()
+	b.SetMaxSize(maxSize)
+	return b
+}
+
+// sets the maximum size of the buffer, 0 means no limit
+func (s *SeekBuffer) SetMaxSize(maxSize int) {
+	if maxSize < 0 {
+		maxSize = 0
+	}
+	s.maxSize = maxSize
+}
+
+// returns the maximum size of the buffer, 0 means no limit
+func (s *SeekBuffer) MaxSize() int {
+	return s.maxSize
+}
+
+// returns the generation of the content, it changes whenever the
+// content is modified and can be used to detect concurrent changes
+func (s *SeekBuffer) Version() uint64 {
+	return s.version
+}
+
+// reports whether the buffer is a read-only view
+func (s *SeekBuffer) ReadOnly() bool {
+	return s.readOnly
+}
+
+// empties the buffer keeping its storage for reuse, the limit, the
+// JSON mode and safe mode are restored to their defaults
+func (s *SeekBuffer) Reset() {
+	s.truncate()
+	s.maxSize = 0
+	s.jsonText = false
+	s.safe = false
+}
+
+// empties the buffer keeping its storage and settings
+func (s *SeekBuffer) truncate() {
+	s.buffer = s.buffer[:0]
+	s.version++
+	s.offset = 0
+	s.lastRead = opInvalid
+}
+
+// returns the capacity of the storage
+func (s *SeekBuffer) Cap() int {
+	return cap(s.buffer)
+}
+
+// returns an independent copy of the buffer including its offset
+func (s *SeekBuffer) Clone() *SeekBuffer {
+	c := *s
+	c.buffer = make([]byte, len(s.buffer))
+	copy(c.buffer, s.buffer)
+	return &c
+}
+
+// returns a read-only view of the bytes between start and end, the view
+// shares storage with the buffer and stays valid until the buffer is
+// closed or compacted
+func (s *SeekBuffer) View(start, end int) (*SeekBuffer, error) {
+	if start < 0 || end > len(s.buffer) || start > end {
+		return nil, ErrOutOfRange
+	}
+	return &SeekBuffer{
+		buffer:   s.buffer[start:end:end],
+		readOnly: true,
+	}, nil
+}
+
+// returns current content of the buffer, the slice references the
+// internal storage unless the buffer was created in safe mode
 func (s *SeekBuffer) Bytes() []byte {
+	if s.safe {
+		return s.BytesCopy()
+	}
 	return s.buffer
 }
 
-// appends content to the buffer
-func (s *SeekBuffer) Append(src []byte) {
+// returns a copy of the current content of the buffer
+func (s *SeekBuffer) BytesCopy() []byte {
+	b := make([]byte, len(s.buffer))
+	copy(b, s.buffer)
+	return b
+}
+
+// appends content to the buffer, fails with ErrBufferFull if the
+// content does not fit, nothing is appended in that case
+func (s *SeekBuffer) Append(src []byte) error {
+	s.lastRead = opInvalid
+	if err := s.checkWrite(len(src)); err != nil {
+		return err
+	}
 	s.buffer = append(s.buffer, src...)
+	s.version++
+	return nil
 }
 
 // writes content to the buffer, alias for Append
 func (s *SeekBuffer) Write(src []byte) (int, error) {
-	s.buffer = append(s.buffer, src...)
+	if err := s.Append(src); err != nil {
+		return 0, err
+	}
 	return len(src), nil
 }
 
+// writes a string to the buffer
+func (s *SeekBuffer) WriteString(str string) (int, error) {
+	s.lastRead = opInvalid
+	if err := s.checkWrite(len(str)); err != nil {
+		return 0, err
+	}
+	s.buffer = append(s.buffer, str...)
+	s.version++
+	return len(str), nil
+}
+
+// writes a single byte to the buffer
+func (s *SeekBuffer) WriteByte(c byte) error {
+	s.lastRead = opInvalid
+	if err := s.checkWrite(1); err != nil {
+		return err
+	}
+	s.buffer = append(s.buffer, c)
+	s.version++
+	return nil
+}
+
+// writes the UTF-8 encoding of r to the buffer
+func (s *SeekBuffer) WriteRune(r rune) (int, error) {
+	s.lastRead = opInvalid
+	n := utf8.RuneLen(r)
+	if n < 0 {
+		n = utf8.RuneLen(utf8.RuneError)
+	}
+	if err := s.checkWrite(n); err != nil {
+		return 0, err
+	}
+	s.buffer = utf8.AppendRune(s.buffer, r)
+	s.version++
+	return n, nil
+}
+
+// checks that n more bytes can be written to the buffer
+func (s *SeekBuffer) checkWrite(n int) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if s.maxSize > 0 && len(s.buffer)+n > s.maxSize {
+		return ErrBufferFull
+	}
+	return nil
+}
+
 // reads content from the buffer into dst
 func (s *SeekBuffer) Read(dst []byte) (int, error) {
+	s.lastRead = opInvalid
 	if s.offset >= len(s.buffer) {
 		return 0, io.EOF
 	}
 
 	n := copy(dst, s.buffer[s.offset:])
 	s.offset += n
+	if n > 0 {
+		s.lastRead = opRead
+	}
 	return n, nil
 }
 
+// reads a single byte from the buffer
+func (s *SeekBuffer) ReadByte() (byte, error) {
+	s.lastRead = opInvalid
+	if s.offset >= len(s.buffer) {
+		return 0, io.EOF
+	}
+	c := s.buffer[s.offset]
+	s.offset++
+	s.lastRead = opRead
+	return c, nil
+}
+
+// reads a single UTF-8 encoded rune from the buffer
+func (s *SeekBuffer) ReadRune() (rune, int, error) {
+	s.lastRead = opInvalid
+	if s.offset >= len(s.buffer) {
+		return 0, 0, io.EOF
+	}
+	r, n := utf8.DecodeRune(s.buffer[s.offset:])
+	s.offset += n
+	s.lastRead = n
+	return r, n, nil
+}
+
+// steps back over the last byte returned by a successful read
+func (s *SeekBuffer) UnreadByte() error {
+	if s.lastRead == opInvalid || s.offset == 0 {
+		return ErrInvalidUnreadByte
+	}
+	s.lastRead = opInvalid
+	s.offset--
+	return nil
+}
+
+// steps back over the last rune returned by ReadRune
+func (s *SeekBuffer) UnreadRune() error {
+	if s.lastRead <= opInvalid {
+		return ErrInvalidUnreadRune
+	}
+	s.offset -= s.lastRead
+	s.lastRead = opInvalid
+	return nil
+}
+
+// returns the unread content as a string
+func (s *SeekBuffer) String() string {
+	if s.offset >= len(s.buffer) {
+		return ""
+	}
+	return string(s.buffer[s.offset:])
+}
+
 // rewinds the buffer to the beginning
 func (s *SeekBuffer) Rewind() {
+	s.lastRead = opInvalid
 	s.offset = 0
 }
 
 // seeks to the offset
 func (s *SeekBuffer) Seek(offset int) {
+	s.lastRead = opInvalid
 	s.offset = offset
 }
 
 // closes the buffer
 func (s *SeekBuffer) Close() error {
+	s.lastRead = opInvalid
 	s.offset = 0
 	s.buffer = nil
+	s.version++
 	return nil
 }
 
 // read bytes up to the first occurrence of c
 func (s *SeekBuffer) ReadBytes(c byte) ([]byte, error) {
+	s.lastRead = opInvalid
 	indexByte := bytes.IndexByte(s.buffer[s.offset:], c)
 	if indexByte == -1 {
 		b := s.buffer[s.offset:]
 		s.offset = len(s.buffer)
+		if len(b) > 0 {
+			s.lastRead = opRead
+		}
 		return b, io.EOF
 	}
 	end := s.offset + indexByte + 1
 	b := s.buffer[s.offset:end]
 	s.offset = end
+	s.lastRead = opRead
 	return b, nil
 }
 
 func (s *SeekBuffer) Len() int {
 	return len(s.buffer) - s.offset
 }
+
+// drops the already read bytes, rewinds the offset to zero
+// and returns the number of bytes reclaimed
+func (s *SeekBuffer) Compact() int {
+	s.lastRead = opInvalid
+	n := s.offset
+	if n > len(s.buffer) {
+		n = len(s.buffer)
+	}
+	if n == 0 {
+		return 0
+	}
+	a := make([]byte, len(s.buffer)-n)
+	copy(a, s.buffer[n:])
+	s.buffer = a
+	s.version++
+	s.offset = 0
+	return n
+}
+
+// returns the next n unread bytes and advances the offset, the slice
+// references the internal storage and is only valid until the next
+// mutating call
+func (s *SeekBuffer) Next(n int) []byte {
+	s.lastRead = opInvalid
+	if s.offset >= len(s.buffer) || n <= 0 {
+		return s.buffer[len(s.buffer):]
+	}
+	end := min(s.offset+n, len(s.buffer))
+	b := s.buffer[s.offset:end]
+	s.offset = end
+	s.lastRead = opRead
+	return b
+}
+
+// returns the unread content without advancing the offset
+func (s *SeekBuffer) unread() []byte {
+	if s.offset >= len(s.buffer) {
+		return nil
+	}
+	return s.buffer[s.offset:]
+}
+
+// returns the index of the first occurrence of sep in the unread
+// content relative to the current offset, or -1 if not present
+func (s *SeekBuffer) Index(sep []byte) int {
+	return bytes.Index(s.unread(), sep)
+}
+
+// returns the index of the first occurrence of c in the unread
+// content relative to the current offset, or -1 if not present
+func (s *SeekBuffer) IndexByte(c byte) int {
+	return bytes.IndexByte(s.unread(), c)
+}
+
+// reports whether sep is within the unread content
+func (s *SeekBuffer) Contains(sep []byte) bool {
+	return s.Index(sep) != -1
+}
+
+// splits the unread content around each occurrence of c into new
+// buffers, the delimiter is not included, the source is consumed
+func (s *SeekBuffer) Split(c byte) []*SeekBuffer {
+	return s.SplitBytes([]byte{c})
+}
+
+// splits the unread content around each occurrence of sep into new
+// buffers, the separator is not included, the source is consumed
+func (s *SeekBuffer) SplitBytes(sep []byte) []*SeekBuffer {
+	s.lastRead = opInvalid
+	u := s.unread()
+	if len(u) == 0 {
+		return nil
+	}
+	parts := bytes.Split(u, sep)
+	buffers := make([]*SeekBuffer, len(parts))
+	for i, p := range parts {
+		buffers[i] = NewSeekBuffer(p)
+	}
+	s.offset = len(s.buffer)
+	return buffers
+}
+
+// reports whether both buffers hold the same content, offsets are ignored
+func (s *SeekBuffer) Equal(other SeekableBuffer) bool {
+	return bytes.Equal(s.buffer, other.Bytes())
+}
+
+// reports whether the unread content of both buffers is the same
+func (s *SeekBuffer) EqualUnread(other SeekableBuffer) bool {
+	return bytes.Equal(s.unread(), unreadBytes(other))
+}
+
+// compares the content of both buffers lexicographically, the result
+// is 0 if equal, -1 if s sorts before other and +1 otherwise
+func (s *SeekBuffer) Compare(other SeekableBuffer) int {
+	return bytes.Compare(s.buffer, other.Bytes())
+}
+
+// compares the unread content of both buffers lexicographically
+func (s *SeekBuffer) CompareUnread(other SeekableBuffer) int {
+	return bytes.Compare(s.unread(), unreadBytes(other))
+}
@@ -194,3 +194,412 @@ func TestReadBytes_NotFound(t *testing.T) {
 		t.Errorf("len should be 9, but got %d", len(b))
 	}
 }
+
+func TestWithLimit(t *testing.T) {
+	buffer := NewSeekBufferWithLimit(4)
+	n, err := buffer.Write([]byte{1, 2, 3})
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if n != 3 {
+		t.Errorf("n should be 3, but got %d", n)
+	}
+	n, err = buffer.Write([]byte{4, 5})
+	if err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+	if n != 0 {
+		t.Errorf("n should be 0, but got %d", n)
+	}
+	if err := buffer.Append([]byte{4}); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if err := buffer.Append([]byte{5}); err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+	if len(buffer.buffer) != 4 {
+		t.Errorf("len should be 4, but got %d", len(buffer.buffer))
+	}
+}
+
+func TestSetMaxSize(t *testing.T) {
+	buffer := NewSeekBuffer([]byte{1, 2, 3})
+	buffer.SetMaxSize(3)
+	if err := buffer.Append([]byte{4}); err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+	buffer.SetMaxSize(0)
+	if err := buffer.Append([]byte{4}); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	buffer := NewSeekBufferWithLimit(10)
+	buffer.Append([]byte{1, 2, 3})
+	buffer.Seek(1)
+	clone := buffer.Clone()
+	if clone.offset != 1 {
+		t.Errorf("offset should be 1, but got %d", clone.offset)
+	}
+	if clone.maxSize != 10 {
+		t.Errorf("maxSize should be 10, but got %d", clone.maxSize)
+	}
+	clone.buffer[0] = 9
+	clone.Append([]byte{4})
+	if buffer.buffer[0] != 1 {
+		t.Errorf("buffer[0] should be 1, but got %d", buffer.buffer[0])
+	}
+	if len(buffer.buffer) != 3 {
+		t.Errorf("len should be 3, but got %d", len(buffer.buffer))
+	}
+}
+
+func TestCompact(t *testing.T) {
+	buffer := NewSeekBuffer([]byte{1, 2, 3, 4, 5})
+	dst := make([]byte, 2)
+	buffer.Read(dst)
+	n := buffer.Compact()
+	if n != 2 {
+		t.Errorf("n should be 2, but got %d", n)
+	}
+	if buffer.offset != 0 {
+		t.Errorf("offset should be 0, but got %d", buffer.offset)
+	}
+	if len(buffer.buffer) != 3 {
+		t.Errorf("len should be 3, but got %d", len(buffer.buffer))
+	}
+	if buffer.buffer[0] != 3 {
+		t.Errorf("buffer[0] should be 3, but got %d", buffer.buffer[0])
+	}
+	if n := buffer.Compact(); n != 0 {
+		t.Errorf("n should be 0, but got %d", n)
+	}
+}
+
+func TestNext(t *testing.T) {
+	buffer := NewSeekBuffer([]byte{1, 2, 3, 4, 5})
+	b := buffer.Next(2)
+	if len(b) != 2 {
+		t.Errorf("len should be 2, but got %d", len(b))
+	}
+	if buffer.offset != 2 {
+		t.Errorf("offset should be 2, but got %d", buffer.offset)
+	}
+	b = buffer.Next(10)
+	if len(b) != 3 {
+		t.Errorf("len should be 3, but got %d", len(b))
+	}
+	if b[0] != 3 {
+		t.Errorf("b[0] should be 3, but got %d", b[0])
+	}
+	b = buffer.Next(1)
+	if len(b) != 0 {
+		t.Errorf("len should be 0, but got %d", len(b))
+	}
+}
+
+func TestWriteString(t *testing.T) {
+	buffer := NewEmptySeekBuffer()
+	n, err := buffer.WriteString("abc")
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if n != 3 {
+		t.Errorf("n should be 3, but got %d", n)
+	}
+	buffer.WriteByte('d')
+	n, _ = buffer.WriteRune('é')
+	if n != 2 {
+		t.Errorf("n should be 2, but got %d", n)
+	}
+	buffer.Seek(1)
+	if buffer.String() != "bcdé" {
+		t.Errorf("string should be bcdé, but got %q", buffer.String())
+	}
+}
+
+func TestWriteString_Limit(t *testing.T) {
+	buffer := NewSeekBufferWithLimit(2)
+	if _, err := buffer.WriteString("abc"); err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+	buffer.WriteByte('a')
+	if _, err := buffer.WriteRune('é'); err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+	if err := buffer.WriteByte('b'); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if err := buffer.WriteByte('c'); err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+}
+
+func TestUnreadByte(t *testing.T) {
+	buffer := NewSeekBuffer([]byte{1, 2, 3})
+	if err := buffer.UnreadByte(); err != ErrInvalidUnreadByte {
+		t.Errorf("error should be ErrInvalidUnreadByte, but got %v", err)
+	}
+	c, _ := buffer.ReadByte()
+	if c != 1 {
+		t.Errorf("c should be 1, but got %d", c)
+	}
+	if err := buffer.UnreadByte(); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if buffer.offset != 0 {
+		t.Errorf("offset should be 0, but got %d", buffer.offset)
+	}
+	if err := buffer.UnreadByte(); err != ErrInvalidUnreadByte {
+		t.Errorf("error should be ErrInvalidUnreadByte, but got %v", err)
+	}
+	dst := make([]byte, 2)
+	buffer.Read(dst)
+	buffer.Append([]byte{4})
+	if err := buffer.UnreadByte(); err != ErrInvalidUnreadByte {
+		t.Errorf("error should be ErrInvalidUnreadByte, but got %v", err)
+	}
+}
+
+func TestUnreadRune(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("éa"))
+	r, n, err := buffer.ReadRune()
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if r != 'é' || n != 2 {
+		t.Errorf("rune should be é of size 2, but got %q of size %d", r, n)
+	}
+	if err := buffer.UnreadRune(); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if buffer.offset != 0 {
+		t.Errorf("offset should be 0, but got %d", buffer.offset)
+	}
+	if err := buffer.UnreadRune(); err != ErrInvalidUnreadRune {
+		t.Errorf("error should be ErrInvalidUnreadRune, but got %v", err)
+	}
+	buffer.ReadByte()
+	if err := buffer.UnreadRune(); err != ErrInvalidUnreadRune {
+		t.Errorf("error should be ErrInvalidUnreadRune, but got %v", err)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("abcabc"))
+	buffer.Seek(1)
+	if i := buffer.Index([]byte("ab")); i != 2 {
+		t.Errorf("index should be 2, but got %d", i)
+	}
+	if i := buffer.IndexByte('a'); i != 2 {
+		t.Errorf("index should be 2, but got %d", i)
+	}
+	if i := buffer.Index([]byte("x")); i != -1 {
+		t.Errorf("index should be -1, but got %d", i)
+	}
+	if !buffer.Contains([]byte("bc")) {
+		t.Errorf("buffer should contain bc")
+	}
+	buffer.Seek(6)
+	if buffer.Contains([]byte("c")) {
+		t.Errorf("buffer should not contain c")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("x|ab|c||d"))
+	buffer.Seek(2)
+	parts := buffer.Split('|')
+	if len(parts) != 4 {
+		t.Errorf("parts should be 4, but got %d", len(parts))
+	}
+	if parts[0].String() != "ab" {
+		t.Errorf("part should be ab, but got %q", parts[0].String())
+	}
+	if parts[2].Len() != 0 {
+		t.Errorf("part should be empty, but got %q", parts[2].String())
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("len should be 0, but got %d", buffer.Len())
+	}
+	if parts := buffer.Split('|'); parts != nil {
+		t.Errorf("parts should be nil, but got %v", parts)
+	}
+}
+
+func TestSplitBytes(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("a\r\nb"))
+	parts := buffer.SplitBytes([]byte("\r\n"))
+	if len(parts) != 2 {
+		t.Errorf("parts should be 2, but got %d", len(parts))
+	}
+	if parts[1].String() != "b" {
+		t.Errorf("part should be b, but got %q", parts[1].String())
+	}
+}
+
+func TestView(t *testing.T) {
+	buffer := NewSeekBuffer([]byte{1, 2, 3, 4, 5})
+	view, err := buffer.View(1, 3)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if view.Len() != 2 {
+		t.Errorf("len should be 2, but got %d", view.Len())
+	}
+	c, _ := view.ReadByte()
+	if c != 2 {
+		t.Errorf("c should be 2, but got %d", c)
+	}
+	if err := view.Append([]byte{9}); err != ErrReadOnly {
+		t.Errorf("error should be ErrReadOnly, but got %v", err)
+	}
+	if _, err := view.WriteString("x"); err != ErrReadOnly {
+		t.Errorf("error should be ErrReadOnly, but got %v", err)
+	}
+	buffer.buffer[2] = 7
+	c, _ = view.ReadByte()
+	if c != 7 {
+		t.Errorf("c should be 7, but got %d", c)
+	}
+	buffer.Append([]byte{6})
+	if buffer.buffer[3] != 4 {
+		t.Errorf("buffer[3] should be 4, but got %d", buffer.buffer[3])
+	}
+}
+
+func TestView_OutOfRange(t *testing.T) {
+	buffer := NewSeekBuffer([]byte{1, 2, 3})
+	if _, err := buffer.View(2, 4); err != ErrOutOfRange {
+		t.Errorf("error should be ErrOutOfRange, but got %v", err)
+	}
+	if _, err := buffer.View(2, 1); err != ErrOutOfRange {
+		t.Errorf("error should be ErrOutOfRange, but got %v", err)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a := NewSeekBuffer([]byte{1, 2, 3})
+	b := NewSeekBuffer([]byte{1, 2, 3})
+	b.Seek(1)
+	if !a.Equal(b) {
+		t.Errorf("buffers should be equal")
+	}
+	if a.EqualUnread(b) {
+		t.Errorf("unread content should differ")
+	}
+	c := NewSeekBuffer([]byte{0, 2, 3})
+	c.Seek(1)
+	if !b.EqualUnread(c) {
+		t.Errorf("unread content should be equal")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := NewSeekBuffer([]byte{1, 2, 3})
+	b := NewSeekBuffer([]byte{1, 2, 4})
+	if r := a.Compare(b); r != -1 {
+		t.Errorf("compare should be -1, but got %d", r)
+	}
+	if r := b.Compare(a); r != 1 {
+		t.Errorf("compare should be 1, but got %d", r)
+	}
+	a.Seek(3)
+	b.Seek(3)
+	if r := a.CompareUnread(b); r != 0 {
+		t.Errorf("compare should be 0, but got %d", r)
+	}
+}
+
+func TestNewSeekBufferWithCapacity(t *testing.T) {
+	buffer := NewSeekBufferWithCapacity(8)
+	if len(buffer.buffer) != 0 {
+		t.Errorf("buffer should be empty, but got %v", buffer.buffer)
+	}
+	if cap(buffer.buffer) != 8 {
+		t.Errorf("cap should be 8, but got %d", cap(buffer.buffer))
+	}
+}
+
+func TestReset(t *testing.T) {
+	buffer := NewSeekBufferWithLimit(10)
+	buffer.Append([]byte{1, 2, 3})
+	buffer.Seek(2)
+	buffer.Reset()
+	if len(buffer.buffer) != 0 {
+		t.Errorf("buffer should be empty, but got %v", buffer.buffer)
+	}
+	if cap(buffer.buffer) < 3 {
+		t.Errorf("cap should be kept, but got %d", cap(buffer.buffer))
+	}
+	if buffer.offset != 0 {
+		t.Errorf("offset should be 0, but got %d", buffer.offset)
+	}
+	if buffer.maxSize != 0 {
+		t.Errorf("maxSize should be 0, but got %d", buffer.maxSize)
+	}
+	safe := NewSafeSeekBuffer([]byte{1})
+	safe.Reset()
+	if safe.safe {
+		t.Errorf("safe mode should be cleared")
+	}
+}
+
+func TestCap(t *testing.T) {
+	buffer := NewSafeSeekBuffer(nil)
+	buffer.buffer = make([]byte, 2, 64)
+	if buffer.Cap() != 64 {
+		t.Errorf("cap should be 64, but got %d", buffer.Cap())
+	}
+}
+
+func TestBytesCopy(t *testing.T) {
+	buffer := NewSeekBuffer([]byte{1, 2, 3})
+	b := buffer.BytesCopy()
+	b[0] = 9
+	if buffer.buffer[0] != 1 {
+		t.Errorf("buffer[0] should be 1, but got %d", buffer.buffer[0])
+	}
+	buffer.Bytes()[0] = 9
+	if buffer.buffer[0] != 9 {
+		t.Errorf("buffer[0] should be 9, but got %d", buffer.buffer[0])
+	}
+}
+
+func TestSafeSeekBuffer(t *testing.T) {
+	buffer := NewSafeSeekBuffer([]byte{1, 2, 3})
+	buffer.Bytes()[0] = 9
+	if buffer.buffer[0] != 1 {
+		t.Errorf("buffer[0] should be 1, but got %d", buffer.buffer[0])
+	}
+	if !buffer.Clone().safe {
+		t.Errorf("clone should keep safe mode")
+	}
+}
+
+func TestVersion(t *testing.T) {
+	buffer := NewEmptySeekBuffer()
+	v := buffer.Version()
+	buffer.Append([]byte{1, 2, 3})
+	if buffer.Version() == v {
+		t.Errorf("version should change after append")
+	}
+	v = buffer.Version()
+	dst := make([]byte, 2)
+	buffer.Read(dst)
+	buffer.Seek(0)
+	if buffer.Version() != v {
+		t.Errorf("version should not change on reads, but got %d", buffer.Version())
+	}
+	buffer.SetMaxSize(3)
+	buffer.WriteByte(4)
+	if buffer.Version() != v {
+		t.Errorf("version should not change on a failed write, but got %d", buffer.Version())
+	}
+	buffer.Seek(1)
+	buffer.Compact()
+	if buffer.Version() == v {
+		t.Errorf("version should change after compact")
+	}
+}
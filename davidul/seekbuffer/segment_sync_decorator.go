@@ -0,0 +1,274 @@
+package seekbuffer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// name of the index file of a segment directory
+const segmentIndexName = "index.json"
+
+// returned when reading bytes whose segment was reclaimed
+var ErrReclaimed = errors.New("seekbuffer: segment reclaimed")
+
+// returned by writes when the wrapped buffer lost or changed bytes which
+// are already on disk, the segments can only be appended to
+var ErrNotAppendOnly = errors.New("seekbuffer: content is not append-only")
+
+// describes the segments of a directory
+type segmentIndex struct {
+	SegmentSize int `json:"segmentSize"`
+	// bytes persisted over all segments including reclaimed ones
+	Length int `json:"length"`
+	// number of the first segment still on disk, starting at 1
+	First int `json:"first"`
+}
+
+func segmentName(dir string, n int) string {
+	return filepath.Join(dir, fmt.Sprintf("%06d.seg", n))
+}
+
+func (idx *segmentIndex) save(dir string) error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	return NewSeekBuffer(data).SaveToFileAtomic(filepath.Join(dir, segmentIndexName))
+}
+
+func loadSegmentIndex(dir string) (*segmentIndex, error) {
+	data, err := os.ReadFile(filepath.Join(dir, segmentIndexName))
+	if err != nil {
+		return nil, err
+	}
+	idx := &segmentIndex{}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, err
+	}
+	if idx.SegmentSize <= 0 || idx.First < 1 || idx.Length < 0 {
+		return nil, ErrInvalidEncoding
+	}
+	return idx, nil
+}
+
+// decorator persisting the wrapped buffer as fixed-size segment files
+// 000001.seg, 000002.seg, ... plus an index in a directory
+type SegmentSyncDecorator struct {
+	buffer SeekableBuffer
+	dir    string
+	index  segmentIndex
+}
+
+var _ SeekableBuffer = (*SegmentSyncDecorator)(nil)
+
+// wraps buffer and persists its current content into dir, which must not
+// already hold segments
+func NewSegmentSyncDecorator(buffer SeekableBuffer, dir string, segmentSize int) (*SegmentSyncDecorator, error) {
+	if segmentSize <= 0 {
+		return nil, ErrOutOfRange
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(dir, segmentIndexName)); err == nil {
+		return nil, &os.PathError{Op: "open", Path: dir, Err: os.ErrExist}
+	}
+	d := &SegmentSyncDecorator{
+		buffer: buffer,
+		dir:    dir,
+		index:  segmentIndex{SegmentSize: segmentSize, First: 1},
+	}
+	if err := d.persist(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// writes every byte not yet on disk to the segments and updates the index
+func (d *SegmentSyncDecorator) persist() error {
+	content := d.buffer.Bytes()
+	for d.index.Length < len(content) {
+		n := d.index.Length/d.index.SegmentSize + 1
+		room := d.index.SegmentSize - d.index.Length%d.index.SegmentSize
+		chunk := content[d.index.Length:min(len(content), d.index.Length+room)]
+		f, err := os.OpenFile(segmentName(d.dir, n), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		written, err := f.Write(chunk)
+		d.index.Length += written
+		if err1 := f.Close(); err == nil {
+			err = err1
+		}
+		if err != nil {
+			d.index.save(d.dir)
+			return err
+		}
+	}
+	return d.index.save(d.dir)
+}
+
+// removes the segments holding only bytes before offset and returns the
+// number of bytes reclaimed, the buffer itself is not changed
+func (d *SegmentSyncDecorator) Reclaim(offset int) (int, error) {
+	last := min(offset, d.index.Length) / d.index.SegmentSize
+	if last < d.index.First {
+		return 0, nil
+	}
+	first := d.index.First
+	d.index.First = last + 1
+	if err := d.index.save(d.dir); err != nil {
+		d.index.First = first
+		return 0, err
+	}
+	for n := first; n <= last; n++ {
+		os.Remove(segmentName(d.dir, n))
+	}
+	return (last - first + 1) * d.index.SegmentSize, nil
+}
+
+func (d *SegmentSyncDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+// appends content to the buffer and persists it
+func (d *SegmentSyncDecorator) Append(src []byte) error {
+	before, err := d.size()
+	if err != nil {
+		return err
+	}
+	if err := d.buffer.Append(src); err != nil {
+		return err
+	}
+	return d.appended(before, len(src))
+}
+
+// writes content to the buffer and persists it
+func (d *SegmentSyncDecorator) Write(src []byte) (int, error) {
+	before, err := d.size()
+	if err != nil {
+		return 0, err
+	}
+	n, err := d.buffer.Write(src)
+	if err != nil {
+		return n, err
+	}
+	return n, d.appended(before, n)
+}
+
+// returns the size of the content, fails once it shrank below what is
+// on disk, e.g. after Close or expiry in a decorator below
+func (d *SegmentSyncDecorator) size() (int, error) {
+	size := len(d.buffer.Bytes())
+	if size < d.index.Length {
+		return 0, ErrNotAppendOnly
+	}
+	return size, nil
+}
+
+// persists n bytes appended to content of size before, fails when the
+// write also changed earlier bytes, e.g. by dropping the oldest ones
+func (d *SegmentSyncDecorator) appended(before, n int) error {
+	if len(d.buffer.Bytes()) != before+n {
+		return ErrNotAppendOnly
+	}
+	return d.persist()
+}
+
+func (d *SegmentSyncDecorator) Read(dst []byte) (int, error) {
+	return d.buffer.Read(dst)
+}
+
+func (d *SegmentSyncDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *SegmentSyncDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+// closes the buffer, the segments stay on disk
+func (d *SegmentSyncDecorator) Close() error {
+	return d.buffer.Close()
+}
+
+func (d *SegmentSyncDecorator) ReadBytes(c byte) ([]byte, error) {
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *SegmentSyncDecorator) Len() int {
+	return d.buffer.Len()
+}
+
+// read access to a segment directory, segments are opened on demand
+type SegmentFile struct {
+	dir   string
+	index *segmentIndex
+}
+
+var _ io.ReaderAt = (*SegmentFile)(nil)
+
+// opens the segment directory written by SegmentSyncDecorator
+func OpenSegmentFile(dir string) (*SegmentFile, error) {
+	idx, err := loadSegmentIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &SegmentFile{dir: dir, index: idx}, nil
+}
+
+// total number of bytes persisted including reclaimed ones
+func (f *SegmentFile) Size() int64 {
+	return int64(f.index.Length)
+}
+
+// offset of the first byte still on disk
+func (f *SegmentFile) Start() int64 {
+	return int64((f.index.First - 1) * f.index.SegmentSize)
+}
+
+// reads len(p) bytes at off loading only the segments involved
+func (f *SegmentFile) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, ErrOutOfRange
+	}
+	if off < f.Start() {
+		return 0, ErrReclaimed
+	}
+	size := int64(f.index.SegmentSize)
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= f.Size() {
+			return n, io.EOF
+		}
+		seg, err := os.Open(segmentName(f.dir, int(pos/size)+1))
+		if err != nil {
+			return n, err
+		}
+		m, err := seg.ReadAt(p[n:min(len(p), n+int(size-pos%size))], pos%size)
+		seg.Close()
+		n += m
+		if err != nil && err != io.EOF {
+			return n, err
+		}
+		if m == 0 {
+			return n, io.ErrUnexpectedEOF
+		}
+	}
+	return n, nil
+}
+
+// loads every byte still on disk into a new buffer
+func (f *SegmentFile) Load() (*SeekBuffer, error) {
+	b := NewSeekBufferWithCapacity(int(f.Size() - f.Start()))
+	b.buffer = b.buffer[:cap(b.buffer)]
+	if _, err := f.ReadAt(b.buffer, f.Start()); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,102 @@
+package seekbuffer
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSegmentSyncDecorator(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "segments")
+	decorator, err := NewSegmentSyncDecorator(NewSeekBuffer([]byte("abc")), dir, 4)
+	if err != nil {
+		t.Fatalf("error should be nil, but got %v", err)
+	}
+	decorator.Write([]byte("defgh"))
+	decorator.Append([]byte("ij"))
+	first, _ := os.ReadFile(filepath.Join(dir, "000001.seg"))
+	third, _ := os.ReadFile(filepath.Join(dir, "000003.seg"))
+	if string(first) != "abcd" || string(third) != "ij" {
+		t.Errorf("segments should be abcd and ij, but got %q and %q", first, third)
+	}
+
+	file, err := OpenSegmentFile(dir)
+	if err != nil {
+		t.Fatalf("error should be nil, but got %v", err)
+	}
+	if file.Size() != 10 {
+		t.Errorf("size should be 10, but got %d", file.Size())
+	}
+	p := make([]byte, 4)
+	n, err := file.ReadAt(p, 3)
+	if err != nil || string(p[:n]) != "defg" {
+		t.Errorf("read should be defg, but got %q, %v", p[:n], err)
+	}
+	n, err = file.ReadAt(p, 8)
+	if err != io.EOF || string(p[:n]) != "ij" {
+		t.Errorf("read should be ij with EOF, but got %q, %v", p[:n], err)
+	}
+	loaded, _ := file.Load()
+	if loaded.String() != "abcdefghij" {
+		t.Errorf("loaded should be abcdefghij, but got %q", loaded.String())
+	}
+}
+
+func TestSegmentSyncDecorator_Reclaim(t *testing.T) {
+	dir := t.TempDir()
+	decorator, _ := NewSegmentSyncDecorator(NewSeekBuffer([]byte("abcdefghij")), dir, 4)
+	n, err := decorator.Reclaim(9)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if n != 8 {
+		t.Errorf("reclaimed should be 8, but got %d", n)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "000002.seg")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("segment 2 should be removed, but got %v", err)
+	}
+	file, _ := OpenSegmentFile(dir)
+	if _, err := file.ReadAt(make([]byte, 1), 7); err != ErrReclaimed {
+		t.Errorf("error should be ErrReclaimed, but got %v", err)
+	}
+	loaded, _ := file.Load()
+	if loaded.String() != "ij" {
+		t.Errorf("loaded should be ij, but got %q", loaded.String())
+	}
+	if n, _ := decorator.Reclaim(9); n != 0 {
+		t.Errorf("reclaimed should be 0, but got %d", n)
+	}
+}
+
+func TestSegmentSyncDecorator_Exists(t *testing.T) {
+	dir := t.TempDir()
+	NewSegmentSyncDecorator(NewEmptySeekBuffer(), dir, 4)
+	if _, err := NewSegmentSyncDecorator(NewEmptySeekBuffer(), dir, 4); !errors.Is(err, os.ErrExist) {
+		t.Errorf("error should be ErrExist, but got %v", err)
+	}
+}
+
+func TestSegmentSyncDecorator_Shrunk(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "segments")
+	decorator, _ := NewSegmentSyncDecorator(NewEmptySeekBuffer(), dir, 4)
+	decorator.Write([]byte("abcd"))
+	decorator.Close()
+	if _, err := decorator.Write([]byte("xy")); err != ErrNotAppendOnly {
+		t.Errorf("write after close should fail with ErrNotAppendOnly, but got %v", err)
+	}
+	first, _ := os.ReadFile(filepath.Join(dir, "000001.seg"))
+	if string(first) != "abcd" {
+		t.Errorf("segment should stay abcd, but got %q", first)
+	}
+}
+
+func TestSegmentSyncDecorator_Rewritten(t *testing.T) {
+	quota, _ := NewQuotaDecorator(NewEmptySeekBuffer(), QuotaConfig{MaxSize: 4, Policy: QuotaDropOldest})
+	decorator, _ := NewSegmentSyncDecorator(quota, filepath.Join(t.TempDir(), "segments"), 4)
+	decorator.Write([]byte("abcd"))
+	if _, err := decorator.Write([]byte("ef")); err != ErrNotAppendOnly {
+		t.Errorf("write dropping persisted bytes should fail with ErrNotAppendOnly, but got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package seekbuffer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// returned by RestoreSnapshot for names without a snapshot
+var ErrUnknownSnapshot = errors.New("seekbuffer: unknown snapshot")
+
+// buffers which can be emptied
+type resetter interface {
+	Reset()
+}
+
+// buffers with a size limit
+type limiter interface {
+	MaxSize() int
+	SetMaxSize(int)
+}
+
+// content and read offset saved under a name
+type snapshot struct {
+	// shared by all snapshots of the same state, never modified
+	content []byte
+	offset  int
+}
+
+// decorator keeping named checkpoints of the wrapped buffer, snapshots of
+// unchanged content share one copy
+type SnapshotDecorator struct {
+	buffer    SeekableBuffer
+	snapshots map[string]snapshot
+	// last copy taken, reused until the content changes
+	shared []byte
+	dirty  bool
+}
+
+var _ SeekableBuffer = (*SnapshotDecorator)(nil)
+
+// wraps buffer, which must support Reset for snapshots to be restored
+func NewSnapshotDecorator(buffer SeekableBuffer) (*SnapshotDecorator, error) {
+	if _, ok := buffer.(resetter); !ok {
+		return nil, errors.New("seekbuffer: snapshots need a buffer with Reset")
+	}
+	return &SnapshotDecorator{
+		buffer:    buffer,
+		snapshots: map[string]snapshot{},
+		dirty:     true,
+	}, nil
+}
+
+// saves the content and read offset under name, replacing an earlier
+// snapshot of that name, changes bypassing the decorator are not tracked
+func (d *SnapshotDecorator) SaveSnapshot(name string) {
+	if d.dirty {
+		d.shared = append([]byte(nil), d.buffer.Bytes()...)
+		d.dirty = false
+	}
+	d.snapshots[name] = snapshot{
+		content: d.shared,
+		offset:  len(d.shared) - d.buffer.Len(),
+	}
+}
+
+// replaces the content and read offset with the snapshot saved under name,
+// the size limit of the buffer is kept and the content is left unchanged
+// when the snapshot does not fit
+func (d *SnapshotDecorator) RestoreSnapshot(name string) error {
+	s, ok := d.snapshots[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownSnapshot, name)
+	}
+	current := append([]byte(nil), d.buffer.Bytes()...)
+	offset := len(current) - d.buffer.Len()
+	if err := d.replace(s.content); err != nil {
+		// the previous content fitted under the same limit
+		d.replace(current)
+		d.buffer.Seek(offset)
+		d.dirty = true
+		return err
+	}
+	d.buffer.Seek(s.offset)
+	d.shared = s.content
+	d.dirty = false
+	return nil
+}
+
+// empties the buffer keeping its size limit and appends content
+func (d *SnapshotDecorator) replace(content []byte) error {
+	if s, ok := d.buffer.(*SeekBuffer); ok {
+		// keeps every setting, not only the limit
+		s.truncate()
+		return s.Append(content)
+	}
+	l, limited := d.buffer.(limiter)
+	maxSize := 0
+	if limited {
+		maxSize = l.MaxSize()
+	}
+	d.buffer.(resetter).Reset()
+	if limited {
+		l.SetMaxSize(maxSize)
+	}
+	return d.buffer.Append(content)
+}
+
+// removes the snapshot saved under name, reports whether it existed
+func (d *SnapshotDecorator) DropSnapshot(name string) bool {
+	_, ok := d.snapshots[name]
+	delete(d.snapshots, name)
+	return ok
+}
+
+// returns the names of the saved snapshots in sorted order
+func (d *SnapshotDecorator) ListSnapshots() []string {
+	names := make([]string, 0, len(d.snapshots))
+	for name := range d.snapshots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (d *SnapshotDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+func (d *SnapshotDecorator) Append(src []byte) error {
+	d.dirty = true
+	return d.buffer.Append(src)
+}
+
+func (d *SnapshotDecorator) Write(src []byte) (int, error) {
+	d.dirty = true
+	return d.buffer.Write(src)
+}
+
+func (d *SnapshotDecorator) Read(dst []byte) (int, error) {
+	return d.buffer.Read(dst)
+}
+
+func (d *SnapshotDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *SnapshotDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+// drops all snapshots and closes the buffer
+func (d *SnapshotDecorator) Close() error {
+	d.snapshots = map[string]snapshot{}
+	d.shared = nil
+	d.dirty = true
+	return d.buffer.Close()
+}
+
+func (d *SnapshotDecorator) ReadBytes(c byte) ([]byte, error) {
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *SnapshotDecorator) Len() int {
+	return d.buffer.Len()
+}
@@ -0,0 +1,86 @@
+package seekbuffer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotDecorator_Restore(t *testing.T) {
+	buffer := NewSeekBufferWithLimit(100)
+	decorator, err := NewSnapshotDecorator(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decorator.Write([]byte("hello"))
+	decorator.Seek(2)
+	decorator.SaveSnapshot("a")
+	decorator.Write([]byte(" world"))
+	decorator.Seek(0)
+
+	if err := decorator.RestoreSnapshot("a"); err != nil {
+		t.Fatal(err)
+	}
+	if string(decorator.Bytes()) != "hello" {
+		t.Errorf("content should be hello, but got %q", decorator.Bytes())
+	}
+	if decorator.Len() != 3 {
+		t.Errorf("offset should be restored to 2, but got %d unread", decorator.Len())
+	}
+	if buffer.MaxSize() != 100 {
+		t.Errorf("limit should be kept, but got %d", buffer.MaxSize())
+	}
+	if err := decorator.RestoreSnapshot("b"); !errors.Is(err, ErrUnknownSnapshot) {
+		t.Errorf("error should be ErrUnknownSnapshot, but got %v", err)
+	}
+}
+
+func TestSnapshotDecorator_SharedCopy(t *testing.T) {
+	decorator, _ := NewSnapshotDecorator(NewEmptySeekBuffer())
+	decorator.Write([]byte("data"))
+	decorator.SaveSnapshot("a")
+	decorator.SaveSnapshot("b")
+	if &decorator.snapshots["a"].content[0] != &decorator.snapshots["b"].content[0] {
+		t.Errorf("snapshots of unchanged content should share one copy")
+	}
+	decorator.Write([]byte("!"))
+	decorator.SaveSnapshot("c")
+	if string(decorator.snapshots["a"].content) != "data" {
+		t.Errorf("earlier snapshot should be unchanged, but got %q", decorator.snapshots["a"].content)
+	}
+}
+
+func TestSnapshotDecorator_List(t *testing.T) {
+	decorator, _ := NewSnapshotDecorator(NewEmptySeekBuffer())
+	decorator.SaveSnapshot("b")
+	decorator.SaveSnapshot("a")
+	if !reflect.DeepEqual(decorator.ListSnapshots(), []string{"a", "b"}) {
+		t.Errorf("snapshots should be a, b, but got %v", decorator.ListSnapshots())
+	}
+	if !decorator.DropSnapshot("a") || decorator.DropSnapshot("a") {
+		t.Errorf("drop should report whether the snapshot existed")
+	}
+	if !reflect.DeepEqual(decorator.ListSnapshots(), []string{"b"}) {
+		t.Errorf("snapshots should be b, but got %v", decorator.ListSnapshots())
+	}
+}
+
+func TestSnapshotDecorator_RestoreTooLarge(t *testing.T) {
+	buffer := NewEmptySeekBuffer()
+	decorator, _ := NewSnapshotDecorator(buffer)
+	decorator.Write([]byte("abcdef"))
+	decorator.SaveSnapshot("big")
+	buffer.Reset()
+	decorator.Write([]byte("xyz"))
+	decorator.Seek(1)
+	buffer.SetMaxSize(3)
+	if err := decorator.RestoreSnapshot("big"); err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+	if string(decorator.Bytes()) != "xyz" || decorator.Len() != 2 {
+		t.Errorf("content should stay xyz at offset 1, but got %q with %d unread", decorator.Bytes(), decorator.Len())
+	}
+	if buffer.MaxSize() != 3 {
+		t.Errorf("limit should stay 3, but got %d", buffer.MaxSize())
+	}
+}
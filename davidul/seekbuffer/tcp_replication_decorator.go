@@ -0,0 +1,291 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// frame types of the replication stream
+const (
+	// replaces the whole content of the follower
+	frameSnapshot byte = 1
+	// appends to the content of the follower
+	frameAppend byte = 2
+)
+
+// returned when the replication stream holds an unknown frame
+var ErrInvalidFrame = errors.New("seekbuffer: invalid replication frame")
+
+// configures TCPReplicationDecorator
+type TCPReplicationConfig struct {
+	// address of the follower
+	Addr string
+	// first delay between reconnect attempts, defaults to 100ms
+	MinBackoff time.Duration
+	// longest delay between reconnect attempts, defaults to 10s
+	MaxBackoff time.Duration
+	// deadline of every frame write, defaults to 5s; a follower which
+	// stops reading is dropped after it instead of blocking the buffer
+	WriteTimeout time.Duration
+	// receives connection and write errors, must not call back into the decorator
+	OnError func(error)
+}
+
+// decorator streaming every write to a follower over TCP; writes never fail
+// because of the connection and a stalled follower delays them by at most
+// WriteTimeout, on every (re)connect the follower first gets the whole
+// content and then the appends as they happen
+type TCPReplicationDecorator struct {
+	mu     sync.Mutex
+	buffer SeekableBuffer
+	config TCPReplicationConfig
+	conn   net.Conn
+	broken chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+var _ SeekableBuffer = (*TCPReplicationDecorator)(nil)
+
+// wraps buffer and starts connecting to the follower in the background
+func NewTCPReplicationDecorator(buffer SeekableBuffer, config TCPReplicationConfig) *TCPReplicationDecorator {
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = 100 * time.Millisecond
+	}
+	if config.MaxBackoff < config.MinBackoff {
+		config.MaxBackoff = max(10*time.Second, config.MinBackoff)
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = 5 * time.Second
+	}
+	d := &TCPReplicationDecorator{
+		buffer: buffer,
+		config: config,
+		broken: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go d.loop()
+	return d
+}
+
+// keeps a connection to the follower until the decorator is closed
+func (d *TCPReplicationDecorator) loop() {
+	defer close(d.done)
+	backoff := d.config.MinBackoff
+	for {
+		if err := d.connect(); err != nil {
+			d.report(err)
+			select {
+			case <-d.stop:
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, d.config.MaxBackoff)
+			continue
+		}
+		backoff = d.config.MinBackoff
+		select {
+		case <-d.stop:
+			return
+		case <-d.broken:
+		}
+	}
+}
+
+// dials the follower and sends the whole content
+func (d *TCPReplicationDecorator) connect() error {
+	dialer := net.Dialer{Timeout: d.config.MaxBackoff}
+	conn, err := dialer.Dial("tcp", d.config.Addr)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	select {
+	case <-d.stop:
+		conn.Close()
+		return nil
+	default:
+	}
+	if err := d.send(conn, frameSnapshot, d.buffer.Bytes()); err != nil {
+		conn.Close()
+		return err
+	}
+	d.conn = conn
+	return nil
+}
+
+// writes one frame to conn
+func (d *TCPReplicationDecorator) send(conn net.Conn, kind byte, payload []byte) error {
+	conn.SetWriteDeadline(time.Now().Add(d.config.WriteTimeout))
+	return writeFrame(conn, kind, payload)
+}
+
+// streams appended bytes, drops the connection on failure
+func (d *TCPReplicationDecorator) replicate(src []byte) {
+	if d.conn == nil || len(src) == 0 {
+		return
+	}
+	if err := d.send(d.conn, frameAppend, src); err != nil {
+		d.conn.Close()
+		d.conn = nil
+		d.report(err)
+		select {
+		case d.broken <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (d *TCPReplicationDecorator) report(err error) {
+	if d.config.OnError != nil {
+		d.config.OnError(err)
+	}
+}
+
+// reports whether a follower is connected
+func (d *TCPReplicationDecorator) Connected() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.conn != nil
+}
+
+func (d *TCPReplicationDecorator) Bytes() []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Bytes()
+}
+
+// appends content to the buffer and streams it to the follower
+func (d *TCPReplicationDecorator) Append(src []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.buffer.Append(src); err != nil {
+		return err
+	}
+	d.replicate(src)
+	return nil
+}
+
+// writes content to the buffer and streams it to the follower
+func (d *TCPReplicationDecorator) Write(src []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n, err := d.buffer.Write(src)
+	d.replicate(src[:n])
+	return n, err
+}
+
+func (d *TCPReplicationDecorator) Read(dst []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Read(dst)
+}
+
+func (d *TCPReplicationDecorator) Rewind() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buffer.Rewind()
+}
+
+func (d *TCPReplicationDecorator) Seek(offset int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buffer.Seek(offset)
+}
+
+// disconnects from the follower and closes the buffer
+func (d *TCPReplicationDecorator) Close() error {
+	d.mu.Lock()
+	select {
+	case <-d.stop:
+	default:
+		close(d.stop)
+	}
+	if d.conn != nil {
+		d.conn.Close()
+		d.conn = nil
+	}
+	d.mu.Unlock()
+	<-d.done
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Close()
+}
+
+func (d *TCPReplicationDecorator) ReadBytes(c byte) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *TCPReplicationDecorator) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Len()
+}
+
+// writes a frame as type byte, big-endian payload length and payload
+func writeFrame(w io.Writer, kind byte, payload []byte) error {
+	var header [5]byte
+	header[0] = kind
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// reads a frame written by writeFrame, payloads longer than limit fail
+// with ErrBufferFull unless limit is 0, the payload grows as it arrives so
+// a damaged length cannot force a large allocation up front
+func readFrame(r io.Reader, limit int) (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	if header[0] != frameSnapshot && header[0] != frameAppend {
+		return 0, nil, ErrInvalidFrame
+	}
+	n := int64(binary.BigEndian.Uint32(header[1:]))
+	if limit > 0 && n > int64(limit) {
+		return 0, nil, ErrBufferFull
+	}
+	var payload bytes.Buffer
+	if _, err := io.CopyN(&payload, r, n); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+	return header[0], payload.Bytes(), nil
+}
+
+// applies a replication stream to the buffer of a follower until r
+// reaches EOF, the settings of the buffer such as its size limit are
+// kept across snapshots
+func ApplyReplicationStream(r io.Reader, buffer *SeekBuffer) error {
+	for {
+		kind, payload, err := readFrame(r, buffer.MaxSize())
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if kind == frameSnapshot {
+			buffer.truncate()
+		}
+		if err := buffer.Append(payload); err != nil {
+			return err
+		}
+	}
+}
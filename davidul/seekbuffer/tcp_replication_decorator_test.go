@@ -0,0 +1,196 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+)
+
+type frame struct {
+	kind    byte
+	payload string
+}
+
+// accepts connections and forwards their frames
+func newFollower(t *testing.T) (net.Listener, chan net.Conn, chan frame) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error should be nil, but got %v", err)
+	}
+	conns := make(chan net.Conn, 4)
+	frames := make(chan frame, 16)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns <- conn
+			go func() {
+				for {
+					kind, payload, err := readFrame(conn, 0)
+					if err != nil {
+						return
+					}
+					frames <- frame{kind, string(payload)}
+				}
+			}()
+		}
+	}()
+	return listener, conns, frames
+}
+
+func nextFrame(t *testing.T, frames chan frame) frame {
+	select {
+	case f := <-frames:
+		return f
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no frame received")
+		return frame{}
+	}
+}
+
+func TestTCPReplicationDecorator(t *testing.T) {
+	listener, _, frames := newFollower(t)
+	defer listener.Close()
+	decorator := NewTCPReplicationDecorator(NewSeekBuffer([]byte("ab")), TCPReplicationConfig{
+		Addr:       listener.Addr().String(),
+		MinBackoff: time.Millisecond,
+	})
+	defer decorator.Close()
+	if decorator.config.WriteTimeout != 5*time.Second {
+		t.Errorf("write timeout should default to 5s, but got %v", decorator.config.WriteTimeout)
+	}
+
+	f := nextFrame(t, frames)
+	if f.kind != frameSnapshot || f.payload != "ab" {
+		t.Errorf("first frame should be snapshot ab, but got %+v", f)
+	}
+	decorator.Write([]byte("c"))
+	f = nextFrame(t, frames)
+	if f.kind != frameAppend || f.payload != "c" {
+		t.Errorf("frame should be append c, but got %+v", f)
+	}
+}
+
+func TestTCPReplicationDecorator_Reconnect(t *testing.T) {
+	listener, conns, frames := newFollower(t)
+	defer listener.Close()
+	decorator := NewTCPReplicationDecorator(NewEmptySeekBuffer(), TCPReplicationConfig{
+		Addr:       listener.Addr().String(),
+		MinBackoff: time.Millisecond,
+	})
+	defer decorator.Close()
+
+	nextFrame(t, frames)
+	conn := <-conns
+	conn.Close()
+	deadline := time.After(5 * time.Second)
+reconnect:
+	for {
+		select {
+		case <-conns:
+			break reconnect
+		case <-deadline:
+			t.Fatalf("follower should be reconnected")
+		default:
+			// keep writing until the broken connection is noticed
+			decorator.Write([]byte("x"))
+			time.Sleep(time.Millisecond)
+		}
+	}
+	for {
+		f := nextFrame(t, frames)
+		if f.kind == frameSnapshot {
+			if len(f.payload) == 0 || f.payload != string(bytes.Repeat([]byte("x"), len(f.payload))) {
+				t.Errorf("snapshot should hold the written content, but got %q", f.payload)
+			}
+			break
+		}
+	}
+	if string(decorator.Bytes()) == "" {
+		t.Errorf("buffer should keep the writes")
+	}
+}
+
+func TestApplyReplicationStream(t *testing.T) {
+	var stream bytes.Buffer
+	writeFrame(&stream, frameAppend, []byte("old"))
+	writeFrame(&stream, frameSnapshot, []byte("ab"))
+	writeFrame(&stream, frameAppend, []byte("c"))
+	follower := NewEmptySeekBuffer()
+	if err := ApplyReplicationStream(&stream, follower); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if follower.String() != "abc" {
+		t.Errorf("follower should be abc, but got %q", follower.String())
+	}
+	stream.Write([]byte{9, 0, 0, 0, 0})
+	if err := ApplyReplicationStream(&stream, follower); err != ErrInvalidFrame {
+		t.Errorf("error should be ErrInvalidFrame, but got %v", err)
+	}
+}
+
+func TestApplyReplicationStream_Limit(t *testing.T) {
+	var stream bytes.Buffer
+	writeFrame(&stream, frameSnapshot, []byte("abcd"))
+	follower := NewSeekBufferWithLimit(4)
+	if err := ApplyReplicationStream(&stream, follower); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if follower.MaxSize() != 4 {
+		t.Errorf("snapshot should keep the limit 4, but got %d", follower.MaxSize())
+	}
+	stream.Write([]byte{frameAppend, 0xff, 0xff, 0xff, 0xff})
+	if err := ApplyReplicationStream(&stream, follower); err != ErrBufferFull {
+		t.Errorf("oversized frame should fail with ErrBufferFull, but got %v", err)
+	}
+	if follower.String() != "abcd" {
+		t.Errorf("follower should stay abcd, but got %q", follower.String())
+	}
+}
+
+func TestTCPReplicationDecorator_StalledFollower(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	accepted := make(chan net.Conn, 4)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			// never reads
+			accepted <- conn
+		}
+	}()
+	decorator := NewTCPReplicationDecorator(NewEmptySeekBuffer(), TCPReplicationConfig{
+		Addr:         listener.Addr().String(),
+		MinBackoff:   time.Hour,
+		WriteTimeout: 50 * time.Millisecond,
+	})
+	defer decorator.Close()
+	conn := <-accepted
+	defer conn.Close()
+
+	chunk := make([]byte, 1<<20)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 64; i++ {
+			decorator.Write(chunk)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("writes should not block on a stalled follower")
+	}
+	if decorator.Len() != 64*len(chunk) {
+		t.Errorf("buffer should keep every write, but got %d bytes", decorator.Len())
+	}
+}
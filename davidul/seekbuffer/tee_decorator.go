@@ -0,0 +1,71 @@
+package seekbuffer
+
+import (
+	"io"
+)
+
+// decorator copying every write of the primary buffer to a mirror,
+// like io.TeeReader the mirror only sees data written after wrapping
+type TeeDecorator struct {
+	buffer SeekableBuffer
+	mirror io.Writer
+}
+
+var _ SeekableBuffer = (*TeeDecorator)(nil)
+
+// wraps primary so that writes also land in mirror
+func NewTeeDecorator(primary SeekableBuffer, mirror io.Writer) *TeeDecorator {
+	return &TeeDecorator{
+		buffer: primary,
+		mirror: mirror,
+	}
+}
+
+func (d *TeeDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+// appends content to the primary buffer and then to the mirror, an error
+// of the mirror is returned after the primary buffer was written
+func (d *TeeDecorator) Append(src []byte) error {
+	if err := d.buffer.Append(src); err != nil {
+		return err
+	}
+	_, err := writeChunks(d.mirror, src)
+	return err
+}
+
+// writes content to the primary buffer and then to the mirror
+func (d *TeeDecorator) Write(src []byte) (int, error) {
+	n, err := d.buffer.Write(src)
+	if err != nil {
+		return n, err
+	}
+	_, err = writeChunks(d.mirror, src[:n])
+	return n, err
+}
+
+func (d *TeeDecorator) Read(dst []byte) (int, error) {
+	return d.buffer.Read(dst)
+}
+
+func (d *TeeDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *TeeDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+// closes the primary buffer, the mirror is left open
+func (d *TeeDecorator) Close() error {
+	return d.buffer.Close()
+}
+
+func (d *TeeDecorator) ReadBytes(c byte) ([]byte, error) {
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *TeeDecorator) Len() int {
+	return d.buffer.Len()
+}
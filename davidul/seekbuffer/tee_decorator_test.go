@@ -0,0 +1,44 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTeeDecorator(t *testing.T) {
+	var mirror bytes.Buffer
+	primary := NewSeekBuffer([]byte("old"))
+	decorator := NewTeeDecorator(primary, &mirror)
+	decorator.Write([]byte("ab"))
+	decorator.Append([]byte("c"))
+	if mirror.String() != "abc" {
+		t.Errorf("mirror should be abc, but got %q", mirror.String())
+	}
+	if primary.String() != "oldabc" {
+		t.Errorf("primary should be oldabc, but got %q", primary.String())
+	}
+}
+
+func TestTeeDecorator_MirrorError(t *testing.T) {
+	mirror := &failingWriter{fail: true}
+	primary := NewEmptySeekBuffer()
+	decorator := NewTeeDecorator(primary, mirror)
+	n, err := decorator.Write([]byte("ab"))
+	if err != errTarget {
+		t.Errorf("error should be errTarget, but got %v", err)
+	}
+	if n != 2 || primary.String() != "ab" {
+		t.Errorf("primary should be written, but got %q", primary.String())
+	}
+}
+
+func TestTeeDecorator_PrimaryError(t *testing.T) {
+	var mirror bytes.Buffer
+	decorator := NewTeeDecorator(NewSeekBufferWithLimit(1), &mirror)
+	if err := decorator.Append([]byte("ab")); err != ErrBufferFull {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+	if mirror.Len() != 0 {
+		t.Errorf("mirror should be empty, but got %q", mirror.String())
+	}
+}
@@ -0,0 +1,152 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"io"
+)
+
+// transforms a block of data
+type TransformFunc func([]byte) ([]byte, error)
+
+// decorator storing encode(src) in the wrapped buffer on every write and
+// serving decode(stored content) to readers; decoding a concatenation of
+// encoded writes must give the concatenation of their decodings, since
+// once the content was decoded only newly stored writes are decoded and
+// appended, offsets refer to the decoded content
+type TransformDecorator struct {
+	buffer SeekableBuffer
+	encode TransformFunc
+	decode TransformFunc
+	offset int
+	// decoded content and the stored length it was decoded from
+	cache    []byte
+	cacheLen int
+	cacheOK  bool
+}
+
+var _ SeekableBuffer = (*TransformDecorator)(nil)
+
+// wraps buffer, a nil function leaves data unchanged in that direction
+func NewTransformDecorator(buffer SeekableBuffer, encode, decode TransformFunc) *TransformDecorator {
+	identity := func(b []byte) ([]byte, error) {
+		return b, nil
+	}
+	if encode == nil {
+		encode = identity
+	}
+	if decode == nil {
+		decode = identity
+	}
+	return &TransformDecorator{
+		buffer: buffer,
+		encode: encode,
+		decode: decode,
+	}
+}
+
+// returns the decoded content, decoding again only after writes
+func (d *TransformDecorator) decoded() ([]byte, error) {
+	stored := d.buffer.Bytes()
+	if d.cacheOK && d.cacheLen == len(stored) {
+		return d.cache, nil
+	}
+	b, err := d.decode(stored)
+	if err != nil {
+		return nil, err
+	}
+	// decode may return its input, the copy keeps later appends to the
+	// cache out of the wrapped buffer's storage
+	d.cache, d.cacheLen, d.cacheOK = append([]byte(nil), b...), len(stored), true
+	return d.cache, nil
+}
+
+// returns the decoded content, nil if it cannot be decoded
+func (d *TransformDecorator) Bytes() []byte {
+	b, _ := d.decoded()
+	return b
+}
+
+// stores the encoded form of src
+func (d *TransformDecorator) Append(src []byte) error {
+	encoded, err := d.encode(src)
+	if err != nil {
+		return err
+	}
+	stored := len(d.buffer.Bytes())
+	if err := d.buffer.Append(encoded); err != nil {
+		d.cacheOK = false
+		return err
+	}
+	// extend the decoded content instead of decoding everything again
+	if d.cacheOK && d.cacheLen == stored && len(d.buffer.Bytes()) == stored+len(encoded) {
+		if part, err := d.decode(encoded); err == nil {
+			d.cache = append(d.cache, part...)
+			d.cacheLen += len(encoded)
+			return nil
+		}
+	}
+	d.cacheOK = false
+	return nil
+}
+
+// stores the encoded form of src, reports len(src) on success
+func (d *TransformDecorator) Write(src []byte) (int, error) {
+	if err := d.Append(src); err != nil {
+		return 0, err
+	}
+	return len(src), nil
+}
+
+// reads decoded content into dst
+func (d *TransformDecorator) Read(dst []byte) (int, error) {
+	b, err := d.decoded()
+	if err != nil {
+		return 0, err
+	}
+	if d.offset >= len(b) {
+		return 0, io.EOF
+	}
+	n := copy(dst, b[d.offset:])
+	d.offset += n
+	return n, nil
+}
+
+func (d *TransformDecorator) Rewind() {
+	d.offset = 0
+}
+
+// seeks to the offset in the decoded content
+func (d *TransformDecorator) Seek(offset int) {
+	d.offset = offset
+}
+
+func (d *TransformDecorator) Close() error {
+	d.offset = 0
+	d.cache, d.cacheOK = nil, false
+	return d.buffer.Close()
+}
+
+// read decoded bytes up to the first occurrence of c
+func (d *TransformDecorator) ReadBytes(c byte) ([]byte, error) {
+	b, err := d.decoded()
+	if err != nil {
+		return nil, err
+	}
+	if d.offset >= len(b) {
+		return nil, io.EOF
+	}
+	rest := b[d.offset:]
+	i := bytes.IndexByte(rest, c)
+	if i == -1 {
+		d.offset = len(b)
+		return rest, io.EOF
+	}
+	d.offset += i + 1
+	return rest[:i+1], nil
+}
+
+// number of unread decoded bytes
+func (d *TransformDecorator) Len() int {
+	b, _ := d.decoded()
+	return len(b) - d.offset
+}
@@ -0,0 +1,95 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func xor(b []byte) ([]byte, error) {
+	out := make([]byte, len(b))
+	for i, c := range b {
+		out[i] = c ^ 0x20
+	}
+	return out, nil
+}
+
+func TestTransformDecorator(t *testing.T) {
+	inner := NewEmptySeekBuffer()
+	decorator := NewTransformDecorator(inner, xor, xor)
+	decorator.Write([]byte("abc"))
+	decorator.Append([]byte("def"))
+	if string(inner.Bytes()) != "ABCDEF" {
+		t.Errorf("stored content should be encoded, but got %q", inner.Bytes())
+	}
+	if string(decorator.Bytes()) != "abcdef" {
+		t.Errorf("content should be decoded, but got %q", decorator.Bytes())
+	}
+	decorator.Seek(2)
+	dst := make([]byte, 3)
+	n, _ := decorator.Read(dst)
+	if string(dst[:n]) != "cde" {
+		t.Errorf("read should be cde, but got %q", dst[:n])
+	}
+	if decorator.Len() != 1 {
+		t.Errorf("len should be 1, but got %d", decorator.Len())
+	}
+}
+
+func TestTransformDecorator_Escaping(t *testing.T) {
+	escape := func(b []byte) ([]byte, error) {
+		return bytes.ReplaceAll(b, []byte("\n"), []byte(`\n`)), nil
+	}
+	unescape := func(b []byte) ([]byte, error) {
+		return bytes.ReplaceAll(b, []byte(`\n`), []byte("\n")), nil
+	}
+	inner := NewEmptySeekBuffer()
+	decorator := NewTransformDecorator(inner, escape, unescape)
+	decorator.Write([]byte("a\nb"))
+	if bytes.IndexByte(inner.Bytes(), '\n') != -1 {
+		t.Errorf("stored content should have no newline, but got %q", inner.Bytes())
+	}
+	line, err := decorator.ReadBytes('\n')
+	if err != nil || string(line) != "a\n" {
+		t.Errorf("line should be a, but got %q, %v", line, err)
+	}
+	line, err = decorator.ReadBytes('\n')
+	if err != io.EOF || string(line) != "b" {
+		t.Errorf("line should be b with EOF, but got %q, %v", line, err)
+	}
+}
+
+func TestTransformDecorator_Errors(t *testing.T) {
+	fail := errors.New("fail")
+	failing := func(b []byte) ([]byte, error) {
+		return nil, fail
+	}
+	decorator := NewTransformDecorator(NewEmptySeekBuffer(), failing, nil)
+	if _, err := decorator.Write([]byte("a")); err != fail {
+		t.Errorf("error should be fail, but got %v", err)
+	}
+	decorator = NewTransformDecorator(NewSeekBuffer([]byte("a")), nil, failing)
+	if _, err := decorator.Read(make([]byte, 1)); err != fail {
+		t.Errorf("error should be fail, but got %v", err)
+	}
+}
+
+func TestTransformDecorator_IncrementalDecode(t *testing.T) {
+	decoded := 0
+	decode := func(b []byte) ([]byte, error) {
+		decoded += len(b)
+		return xor(b)
+	}
+	decorator := NewTransformDecorator(NewEmptySeekBuffer(), xor, decode)
+	for i := 0; i < 100; i++ {
+		decorator.Write([]byte("ab"))
+		decorator.Len()
+	}
+	if decoded > 2*200 {
+		t.Errorf("interleaved writes and reads should decode each write once, but decoded %d bytes", decoded)
+	}
+	if string(decorator.Bytes()) != string(bytes.Repeat([]byte("ab"), 100)) {
+		t.Errorf("content should be decoded, but got %q", decorator.Bytes())
+	}
+}
@@ -0,0 +1,204 @@
+package seekbuffer
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// configures TTLDecorator
+type TTLConfig struct {
+	// records older than this are expired every SweepInterval, the
+	// sweeper only runs when both are positive
+	MaxAge        time.Duration
+	SweepInterval time.Duration
+	// defaults to time.Now
+	Now func() time.Time
+}
+
+// a record written to the wrapped buffer
+type ttlRecord struct {
+	at time.Time
+	// offset just past the record
+	end int
+}
+
+// decorator timestamping every write and dropping records once they age
+// out, safe for concurrent use
+type TTLDecorator struct {
+	mu      sync.Mutex
+	buffer  SeekableBuffer
+	config  TTLConfig
+	records []ttlRecord
+	expired int
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+var _ SeekableBuffer = (*TTLDecorator)(nil)
+
+// wraps buffer, which must support Compact, present content counts as
+// written now
+func NewTTLDecorator(buffer SeekableBuffer, config TTLConfig) (*TTLDecorator, error) {
+	if _, ok := buffer.(compacter); !ok {
+		return nil, errors.New("seekbuffer: expiry needs a buffer with Compact")
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	d := &TTLDecorator{
+		buffer: buffer,
+		config: config,
+	}
+	if size := len(buffer.Bytes()); size > 0 {
+		d.records = append(d.records, ttlRecord{at: config.Now(), end: size})
+	}
+	if config.MaxAge > 0 && config.SweepInterval > 0 {
+		d.stop = make(chan struct{})
+		d.done = make(chan struct{})
+		go d.loop()
+	}
+	return d, nil
+}
+
+// expires aged out records every interval until stopped
+func (d *TTLDecorator) loop() {
+	defer close(d.done)
+	ticker := time.NewTicker(d.config.SweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			d.Expire(d.config.MaxAge)
+		}
+	}
+}
+
+// drops the records written more than olderThan ago and returns the
+// number of bytes dropped, the read offset keeps pointing at the same data
+func (d *TTLDecorator) Expire(olderThan time.Duration) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	deadline := d.config.Now().Add(-olderThan)
+	n := 0
+	for n < len(d.records) && d.records[n].at.Before(deadline) {
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	cut := d.records[n-1].end
+	offset := len(d.buffer.Bytes()) - d.buffer.Len()
+	d.buffer.Seek(cut)
+	d.buffer.(compacter).Compact()
+	d.buffer.Seek(max(0, offset-cut))
+	d.records = append(d.records[:0], d.records[n:]...)
+	for i := range d.records {
+		d.records[i].end -= cut
+	}
+	d.expired += cut
+	return cut
+}
+
+// number of bytes dropped by Expire so far
+func (d *TTLDecorator) Expired() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.expired
+}
+
+// write time of the oldest record, false when there is none
+func (d *TTLDecorator) Oldest() (time.Time, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.records) == 0 {
+		return time.Time{}, false
+	}
+	return d.records[0].at, true
+}
+
+// timestamps the content written since the last record
+func (d *TTLDecorator) stamp() {
+	size := len(d.buffer.Bytes())
+	last := 0
+	if len(d.records) > 0 {
+		last = d.records[len(d.records)-1].end
+	}
+	if size > last {
+		d.records = append(d.records, ttlRecord{at: d.config.Now(), end: size})
+	}
+}
+
+func (d *TTLDecorator) Bytes() []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Bytes()
+}
+
+// appends src as one record
+func (d *TTLDecorator) Append(src []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	err := d.buffer.Append(src)
+	d.stamp()
+	return err
+}
+
+// writes src as one record
+func (d *TTLDecorator) Write(src []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n, err := d.buffer.Write(src)
+	d.stamp()
+	return n, err
+}
+
+func (d *TTLDecorator) Read(dst []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Read(dst)
+}
+
+func (d *TTLDecorator) Rewind() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buffer.Rewind()
+}
+
+func (d *TTLDecorator) Seek(offset int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buffer.Seek(offset)
+}
+
+// stops the sweeper and closes the buffer
+func (d *TTLDecorator) Close() error {
+	if d.stop != nil {
+		d.mu.Lock()
+		select {
+		case <-d.stop:
+		default:
+			close(d.stop)
+		}
+		d.mu.Unlock()
+		<-d.done
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.records = nil
+	return d.buffer.Close()
+}
+
+func (d *TTLDecorator) ReadBytes(c byte) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *TTLDecorator) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.buffer.Len()
+}
@@ -0,0 +1,77 @@
+package seekbuffer
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTTLDecorator_Expire(t *testing.T) {
+	now := time.Unix(1000, 0)
+	decorator, err := NewTTLDecorator(NewEmptySeekBuffer(), TTLConfig{Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decorator.Write([]byte("old\n"))
+	now = now.Add(time.Minute)
+	decorator.Write([]byte("new\n"))
+	decorator.Seek(6)
+
+	n := decorator.Expire(30 * time.Second)
+	if n != 4 {
+		t.Errorf("expire should drop 4 bytes, but got %d", n)
+	}
+	if string(decorator.Bytes()) != "new\n" {
+		t.Errorf("content should be new, but got %q", decorator.Bytes())
+	}
+	if decorator.Len() != 2 {
+		t.Errorf("unread length should be 2, but got %d", decorator.Len())
+	}
+	if decorator.Expire(30*time.Second) != 0 {
+		t.Errorf("second expire should drop nothing")
+	}
+	if decorator.Expired() != 4 {
+		t.Errorf("expired should be 4, but got %d", decorator.Expired())
+	}
+}
+
+func TestTTLDecorator_Sweeper(t *testing.T) {
+	decorator, err := NewTTLDecorator(NewEmptySeekBuffer(), TTLConfig{
+		MaxAge:        time.Millisecond,
+		SweepInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decorator.Close()
+	decorator.Write([]byte("data"))
+	deadline := time.Now().Add(2 * time.Second)
+	for len(decorator.Bytes()) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if len(decorator.Bytes()) != 0 {
+		t.Errorf("sweeper should expire the data, but got %q", decorator.Bytes())
+	}
+}
+
+func TestTTLDecorator_NeedsCompact(t *testing.T) {
+	if _, err := NewTTLDecorator(NewReadOnlyDecorator(NewEmptySeekBuffer()), TTLConfig{}); err == nil {
+		t.Errorf("buffer without Compact should be rejected")
+	}
+}
+
+func TestTTLDecorator_ConcurrentClose(t *testing.T) {
+	decorator, _ := NewTTLDecorator(NewEmptySeekBuffer(), TTLConfig{
+		MaxAge:        time.Millisecond,
+		SweepInterval: time.Millisecond,
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			decorator.Close()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,207 @@
+package seekbuffer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// describes a version captured by VersioningDecorator
+type VersionInfo struct {
+	// starts at 1
+	Number int
+	// empty unless captured by Tag
+	Tag  string
+	Size int
+	Time time.Time
+}
+
+// difference between two versions, the content of the newer one is the
+// older one with Removed replaced by Added at Offset
+type VersionDiff struct {
+	Offset  int
+	Removed []byte
+	Added   []byte
+}
+
+// a captured version, stored in full or as a delta on its predecessor
+type version struct {
+	info VersionInfo
+	// full content of keyframes
+	full []byte
+	// for deltas, the predecessor's first prefix bytes followed by suffix
+	prefix int
+	suffix []byte
+}
+
+// decorator capturing immutable versions of the wrapped buffer, versions
+// are stored as deltas with a full copy every keyframe interval
+type VersioningDecorator struct {
+	buffer   SeekableBuffer
+	keyframe int
+	versions []version
+	// content of the latest version
+	head []byte
+}
+
+var _ SeekableBuffer = (*VersioningDecorator)(nil)
+
+// wraps buffer without any version, keyframe is the number of versions
+// between full copies and defaults to 16
+func NewVersioningDecorator(buffer SeekableBuffer, keyframe int) *VersioningDecorator {
+	if keyframe <= 0 {
+		keyframe = 16
+	}
+	return &VersioningDecorator{
+		buffer:   buffer,
+		keyframe: keyframe,
+	}
+}
+
+// captures the current content as a new version and returns its number
+func (d *VersioningDecorator) Commit() int {
+	return d.capture("")
+}
+
+// captures the current content as a new version labelled name
+func (d *VersioningDecorator) Tag(name string) (int, error) {
+	if name == "" {
+		return 0, errors.New("seekbuffer: empty version tag")
+	}
+	if _, ok := d.VersionByTag(name); ok {
+		return 0, fmt.Errorf("seekbuffer: version tag %q already exists", name)
+	}
+	return d.capture(name), nil
+}
+
+func (d *VersioningDecorator) capture(tag string) int {
+	content := d.buffer.Bytes()
+	v := version{info: VersionInfo{
+		Number: len(d.versions) + 1,
+		Tag:    tag,
+		Size:   len(content),
+		Time:   time.Now(),
+	}}
+	if len(d.versions)%d.keyframe == 0 {
+		v.full = append([]byte(nil), content...)
+	} else {
+		n := commonPrefix(d.head, content)
+		v.prefix = n
+		v.suffix = append([]byte(nil), content[n:]...)
+	}
+	d.versions = append(d.versions, v)
+	d.head = append(d.head[:0], content...)
+	return v.info.Number
+}
+
+// returns the length of the common prefix of a and b
+func commonPrefix(a, b []byte) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// returns the captured versions, oldest first
+func (d *VersioningDecorator) ListVersions() []VersionInfo {
+	infos := make([]VersionInfo, len(d.versions))
+	for i, v := range d.versions {
+		infos[i] = v.info
+	}
+	return infos
+}
+
+// returns the number of the version labelled name
+func (d *VersioningDecorator) VersionByTag(name string) (int, bool) {
+	for _, v := range d.versions {
+		if v.info.Tag == name {
+			return v.info.Number, true
+		}
+	}
+	return 0, false
+}
+
+// rebuilds the content of version n
+func (d *VersioningDecorator) content(n int) ([]byte, error) {
+	if n < 1 || n > len(d.versions) {
+		return nil, ErrOutOfRange
+	}
+	i := (n - 1) / d.keyframe * d.keyframe
+	content := append([]byte(nil), d.versions[i].full...)
+	for _, v := range d.versions[i+1 : n] {
+		content = append(content[:v.prefix], v.suffix...)
+	}
+	return content, nil
+}
+
+// returns the content of version n as a read-only buffer
+func (d *VersioningDecorator) ReadVersion(n int) (*ReadOnlyDecorator, error) {
+	content, err := d.content(n)
+	if err != nil {
+		return nil, err
+	}
+	return NewReadOnlyDecorator(NewSeekBuffer(content)), nil
+}
+
+// returns the change turning version a into version b
+func (d *VersioningDecorator) DiffVersions(a, b int) (VersionDiff, error) {
+	from, err := d.content(a)
+	if err != nil {
+		return VersionDiff{}, err
+	}
+	to, err := d.content(b)
+	if err != nil {
+		return VersionDiff{}, err
+	}
+	prefix := commonPrefix(from, to)
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix &&
+		from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+	return VersionDiff{
+		Offset:  prefix,
+		Removed: from[prefix : len(from)-suffix],
+		Added:   to[prefix : len(to)-suffix],
+	}, nil
+}
+
+func (d *VersioningDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+func (d *VersioningDecorator) Append(src []byte) error {
+	return d.buffer.Append(src)
+}
+
+func (d *VersioningDecorator) Write(src []byte) (int, error) {
+	return d.buffer.Write(src)
+}
+
+func (d *VersioningDecorator) Read(dst []byte) (int, error) {
+	return d.buffer.Read(dst)
+}
+
+func (d *VersioningDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *VersioningDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+// closes the buffer, captured versions stay readable
+func (d *VersioningDecorator) Close() error {
+	return d.buffer.Close()
+}
+
+func (d *VersioningDecorator) ReadBytes(c byte) ([]byte, error) {
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *VersioningDecorator) Len() int {
+	return d.buffer.Len()
+}
@@ -0,0 +1,70 @@
+package seekbuffer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestVersioningDecorator_ReadVersion(t *testing.T) {
+	decorator := NewVersioningDecorator(NewEmptySeekBuffer(), 3)
+	for i := 0; i < 7; i++ {
+		decorator.Write([]byte(fmt.Sprint(i)))
+		decorator.Commit()
+	}
+	for n := 1; n <= 7; n++ {
+		v, err := decorator.ReadVersion(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(v.Bytes()) != "0123456"[:n] {
+			t.Errorf("version %d should be %q, but got %q", n, "0123456"[:n], v.Bytes())
+		}
+		if err := v.Append([]byte("x")); err != ErrReadOnly {
+			t.Errorf("version should be read-only, but got %v", err)
+		}
+	}
+	if _, err := decorator.ReadVersion(8); err != ErrOutOfRange {
+		t.Errorf("unknown version should fail with ErrOutOfRange, but got %v", err)
+	}
+}
+
+func TestVersioningDecorator_Tag(t *testing.T) {
+	decorator := NewVersioningDecorator(NewEmptySeekBuffer(), 0)
+	decorator.Write([]byte("a"))
+	n, err := decorator.Tag("first")
+	if err != nil || n != 1 {
+		t.Errorf("tag should capture version 1, but got %d, %v", n, err)
+	}
+	if _, err := decorator.Tag("first"); err == nil {
+		t.Errorf("duplicate tag should fail")
+	}
+	if n, ok := decorator.VersionByTag("first"); !ok || n != 1 {
+		t.Errorf("tag should resolve to version 1, but got %d", n)
+	}
+	versions := decorator.ListVersions()
+	if len(versions) != 1 || versions[0].Tag != "first" || versions[0].Size != 1 {
+		t.Errorf("versions should list the tagged version, but got %+v", versions)
+	}
+}
+
+func TestVersioningDecorator_DiffVersions(t *testing.T) {
+	buffer := NewEmptySeekBuffer()
+	decorator := NewVersioningDecorator(buffer, 0)
+	decorator.Write([]byte("hello world"))
+	decorator.Commit()
+	buffer.Reset()
+	decorator.Write([]byte("hello there world"))
+	decorator.Commit()
+
+	diff, err := decorator.DiffVersions(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.Offset != 6 || string(diff.Removed) != "" || string(diff.Added) != "there " {
+		t.Errorf("diff should insert 'there ' at 6, but got %+v", diff)
+	}
+	v, _ := decorator.ReadVersion(2)
+	if string(v.Bytes()) != "hello there world" {
+		t.Errorf("version 2 should be rebuilt from its delta, but got %q", v.Bytes())
+	}
+}
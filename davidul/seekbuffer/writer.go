@@ -0,0 +1,36 @@
+package seekbuffer
+
+import (
+	"io"
+)
+
+// size of the chunks written by SaveToWriter and UnreadToWriter
+const writeChunkSize = 32 * 1024
+
+// streams the whole buffer to w in chunks, the offset is not changed
+func (s *SeekBuffer) SaveToWriter(w io.Writer) (int64, error) {
+	return writeChunks(w, s.buffer)
+}
+
+// streams the unread content to w in chunks, the offset is not changed
+func (s *SeekBuffer) UnreadToWriter(w io.Writer) (int64, error) {
+	return writeChunks(w, s.unread())
+}
+
+// writes data to w directly from the given storage without copying it
+func writeChunks(w io.Writer, data []byte) (int64, error) {
+	var total int64
+	for len(data) > 0 {
+		chunk := data[:min(len(data), writeChunkSize)]
+		n, err := w.Write(chunk)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+		if n < len(chunk) {
+			return total, io.ErrShortWrite
+		}
+		data = data[n:]
+	}
+	return total, nil
+}
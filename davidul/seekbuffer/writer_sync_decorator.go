@@ -0,0 +1,96 @@
+package seekbuffer
+
+import (
+	"io"
+)
+
+// decorator mirroring every write of the wrapped buffer to an io.Writer
+type WriterSyncDecorator struct {
+	buffer SeekableBuffer
+	target io.Writer
+}
+
+var _ SeekableBuffer = (*WriterSyncDecorator)(nil)
+
+// wraps buffer, mirroring stays off until EnableSync is called
+func NewWriterSyncDecorator(buffer SeekableBuffer) *WriterSyncDecorator {
+	return &WriterSyncDecorator{
+		buffer: buffer,
+	}
+}
+
+// writes the current content of the buffer to w and mirrors every
+// following write to it
+func (d *WriterSyncDecorator) EnableSync(w io.Writer) error {
+	if _, err := writeChunks(w, d.buffer.Bytes()); err != nil {
+		return err
+	}
+	d.target = w
+	return nil
+}
+
+// stops mirroring writes
+func (d *WriterSyncDecorator) DisableSync() {
+	d.target = nil
+}
+
+// reports whether writes are mirrored
+func (d *WriterSyncDecorator) IsSyncEnabled() bool {
+	return d.target != nil
+}
+
+// mirrors src to the target when sync is enabled
+func (d *WriterSyncDecorator) sync(src []byte) error {
+	if d.target == nil {
+		return nil
+	}
+	_, err := writeChunks(d.target, src)
+	return err
+}
+
+func (d *WriterSyncDecorator) Bytes() []byte {
+	return d.buffer.Bytes()
+}
+
+// appends content to the buffer and mirrors it to the target
+func (d *WriterSyncDecorator) Append(src []byte) error {
+	if err := d.buffer.Append(src); err != nil {
+		return err
+	}
+	return d.sync(src)
+}
+
+// writes content to the buffer and mirrors it to the target
+func (d *WriterSyncDecorator) Write(src []byte) (int, error) {
+	n, err := d.buffer.Write(src)
+	if err != nil {
+		return n, err
+	}
+	return n, d.sync(src[:n])
+}
+
+func (d *WriterSyncDecorator) Read(dst []byte) (int, error) {
+	return d.buffer.Read(dst)
+}
+
+func (d *WriterSyncDecorator) Rewind() {
+	d.buffer.Rewind()
+}
+
+func (d *WriterSyncDecorator) Seek(offset int) {
+	d.buffer.Seek(offset)
+}
+
+// closes the buffer and stops mirroring, the target is left open
+func (d *WriterSyncDecorator) Close() error {
+	d.target = nil
+	return d.buffer.Close()
+}
+
+func (d *WriterSyncDecorator) ReadBytes(c byte) ([]byte, error) {
+	return d.buffer.ReadBytes(c)
+}
+
+func (d *WriterSyncDecorator) Len() int {
+	return d.buffer.Len()
+}
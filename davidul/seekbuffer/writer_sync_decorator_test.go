@@ -0,0 +1,54 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestWriterSyncDecorator(t *testing.T) {
+	decorator := NewWriterSyncDecorator(NewSeekBuffer([]byte("ab")))
+	decorator.Append([]byte("c"))
+	var target bytes.Buffer
+	if err := decorator.EnableSync(&target); err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if !decorator.IsSyncEnabled() {
+		t.Errorf("sync should be enabled")
+	}
+	decorator.Write([]byte("de"))
+	decorator.Append([]byte("f"))
+	if target.String() != "abcdef" {
+		t.Errorf("target should be abcdef, but got %q", target.String())
+	}
+	decorator.DisableSync()
+	decorator.Write([]byte("g"))
+	if target.String() != "abcdef" {
+		t.Errorf("target should be abcdef, but got %q", target.String())
+	}
+	if string(decorator.Bytes()) != "abcdefg" {
+		t.Errorf("buffer should be abcdefg, but got %q", decorator.Bytes())
+	}
+}
+
+func TestWriterSyncDecorator_BufferError(t *testing.T) {
+	decorator := NewWriterSyncDecorator(NewSeekBufferWithLimit(2))
+	var target bytes.Buffer
+	decorator.EnableSync(&target)
+	if _, err := decorator.Write([]byte("abc")); !errors.Is(err, ErrBufferFull) {
+		t.Errorf("error should be ErrBufferFull, but got %v", err)
+	}
+	if target.Len() != 0 {
+		t.Errorf("target should be empty, but got %q", target.String())
+	}
+}
+
+func TestWriterSyncDecorator_Close(t *testing.T) {
+	decorator := NewWriterSyncDecorator(NewEmptySeekBuffer())
+	var target bytes.Buffer
+	decorator.EnableSync(&target)
+	decorator.Close()
+	if decorator.IsSyncEnabled() {
+		t.Errorf("sync should be disabled after close")
+	}
+}
@@ -0,0 +1,55 @@
+package seekbuffer
+
+import (
+	"bytes"
+	"testing"
+)
+
+// records the size of every write
+type recordingWriter struct {
+	bytes.Buffer
+	writes []int
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, len(p))
+	return w.Buffer.Write(p)
+}
+
+func TestSaveToWriter(t *testing.T) {
+	src := bytes.Repeat([]byte{1}, writeChunkSize+10)
+	buffer := NewSeekBuffer(src)
+	w := &recordingWriter{}
+	n, err := buffer.SaveToWriter(w)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if n != int64(len(src)) {
+		t.Errorf("n should be %d, but got %d", len(src), n)
+	}
+	if len(w.writes) != 2 {
+		t.Errorf("writes should be 2, but got %d", len(w.writes))
+	}
+	if !bytes.Equal(w.Bytes(), src) {
+		t.Errorf("content should match")
+	}
+}
+
+func TestUnreadToWriter(t *testing.T) {
+	buffer := NewSeekBuffer([]byte("hello"))
+	buffer.Seek(3)
+	var w bytes.Buffer
+	n, err := buffer.UnreadToWriter(&w)
+	if err != nil {
+		t.Errorf("error should be nil, but got %v", err)
+	}
+	if n != 2 {
+		t.Errorf("n should be 2, but got %d", n)
+	}
+	if w.String() != "lo" {
+		t.Errorf("content should be lo, but got %q", w.String())
+	}
+	if buffer.offset != 3 {
+		t.Errorf("offset should be 3, but got %d", buffer.offset)
+	}
+}